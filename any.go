@@ -0,0 +1,132 @@
+package goption
+
+import (
+	"iter"
+	"reflect"
+)
+
+// AnyOption is implemented by every Option, whatever its T. It lets code such
+// as loggers and diff tools treat an Option[int] and an Option[string] alike.
+// Types which embed an Option, such as NonNull, implement it too.
+type AnyOption interface {
+	// Ok returns true if the underlying value is present.
+	Ok() bool
+
+	// OptionAny returns the underlying value as an any and a boolean
+	// indicating if it's present.
+	OptionAny() (any, bool)
+
+	// ElemType returns T.
+	ElemType() reflect.Type
+}
+
+var anyOptionType = reflect.TypeFor[AnyOption]()
+
+// IsOptionType returns true if values of type t are options, which is when t
+// is an Option or a struct embedding one.
+func IsOptionType(t reflect.Type) bool {
+	return t != nil && t.Kind() == reflect.Struct && t.Implements(anyOptionType)
+}
+
+// ElemType returns the type of the underlying value, T.
+func (o Option[T]) ElemType() reflect.Type {
+	return reflect.TypeFor[T]()
+}
+
+// OptionFields yields every exported Option field of the struct v, or of the
+// struct v points to, along with its name. Fields holding a pointer to an
+// Option yield the Option, or an empty one if the pointer is nil. Nested and pointed to structs are
+// walked too, and their fields are named by a dotted path such as
+// "Address.Zip". Fields of embedded structs are named as if they were
+// declared in the outer struct.
+func OptionFields(v any) iter.Seq2[string, AnyOption] {
+	return func(yield func(string, AnyOption) bool) {
+		walkOptions(reflect.ValueOf(v), "", map[uintptr]bool{}, yield)
+	}
+}
+
+// Presence returns the name of every Option field of v, as given by
+// OptionFields, mapped to whether its value is present.
+func Presence(v any) map[string]bool {
+	presence := map[string]bool{}
+	for name, o := range OptionFields(v) {
+		presence[name] = o.Ok()
+	}
+
+	return presence
+}
+
+// fieldOption returns the option held by the field v, which may be a pointer
+// to one. A nil pointer to an option gives an empty option.
+func fieldOption(v reflect.Value) (AnyOption, bool) {
+	t := v.Type()
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if !IsOptionType(t) || !v.CanInterface() {
+		return nil, false
+	}
+
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return reflect.Zero(t).Interface().(AnyOption), true
+		}
+		v = v.Elem()
+	}
+
+	return v.Interface().(AnyOption), true
+}
+
+// walkOptions calls yield for every Option field in v, prefixing their names
+// with prefix. Pointers in walking lead to the struct containing v, so
+// following them again would loop forever. It returns false if yield asked to
+// stop.
+func walkOptions(v reflect.Value, prefix string, walking map[uintptr]bool, yield func(string, AnyOption) bool) bool {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return true
+		}
+		if v.Kind() == reflect.Pointer {
+			// The same pointer may be reached through other fields, whose
+			// options are yielded under their own names.
+			p := v.Pointer()
+			if walking[p] {
+				return true
+			}
+			walking[p] = true
+			defer delete(walking, p)
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return true
+	}
+
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() && !field.Anonymous {
+			continue
+		}
+
+		// The exported fields of unexported embedded structs are still
+		// readable, like with encoding/json.
+		value := v.Field(i)
+		if o, isOption := fieldOption(value); isOption {
+			if !yield(prefix+field.Name, o) {
+				return false
+			}
+			continue
+		}
+
+		nested := prefix + field.Name + "."
+		if field.Anonymous {
+			nested = prefix
+		}
+		if !walkOptions(value, nested, walking, yield) {
+			return false
+		}
+	}
+
+	return true
+}
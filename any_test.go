@@ -0,0 +1,124 @@
+package goption
+
+import (
+	"reflect"
+	"testing"
+)
+
+type anyAddress struct {
+	Street string
+	Zip    Option[string]
+}
+
+type anyBase struct {
+	ID Option[int]
+}
+
+type anyUser struct {
+	anyBase
+	Name     Option[string]
+	Nick     NonNull[string]
+	Age      int
+	Home     anyAddress
+	Work     *anyAddress
+	Previous *anyAddress
+	Next     *anyUser
+	secret   Option[int]
+}
+
+func TestAnyOption(t *testing.T) {
+	var opts []AnyOption = []AnyOption{Some(3), None[string](), NonNull[bool]{Some(true)}}
+	types := []reflect.Type{reflect.TypeFor[int](), reflect.TypeFor[string](), reflect.TypeFor[bool]()}
+	for i, o := range opts {
+		if o.ElemType() != types[i] {
+			t.Errorf("Expected element type %v, got %v", types[i], o.ElemType())
+		}
+		if _, ok := o.OptionAny(); ok != o.Ok() {
+			t.Errorf("Expected OptionAny to agree with Ok for %v", o)
+		}
+	}
+}
+
+func TestIsOptionType(t *testing.T) {
+	for typ, want := range map[reflect.Type]bool{
+		reflect.TypeFor[Option[int]]():            true,
+		reflect.TypeFor[NonNull[string]]():        true,
+		reflect.TypeFor[struct{ Option[bool] }](): true,
+		reflect.TypeFor[*Option[int]]():           false,
+		reflect.TypeFor[anyUser]():                false,
+		reflect.TypeFor[AnyOption]():              false,
+		reflect.TypeFor[int]():                    false,
+	} {
+		if got := IsOptionType(typ); got != want {
+			t.Errorf("IsOptionType(%v) = %v, want %v", typ, got, want)
+		}
+	}
+	if IsOptionType(nil) {
+		t.Errorf("Expected nil not to be an option type")
+	}
+}
+
+func TestElemTypeInterface(t *testing.T) {
+	if typ := None[error]().ElemType(); typ != reflect.TypeFor[error]() {
+		t.Errorf("Expected error, got %v", typ)
+	}
+}
+
+func TestOptionFields(t *testing.T) {
+	u := &anyUser{
+		anyBase: anyBase{ID: Some(1)},
+		Nick:    NonNull[string]{Some("j")},
+		Work:    &anyAddress{Zip: Some("12345")},
+		secret:  Some(2),
+	}
+	u.Next = u
+
+	var names []string
+	for name := range OptionFields(u) {
+		names = append(names, name)
+	}
+
+	expected := []string{"ID", "Name", "Nick", "Home.Zip", "Work.Zip"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("Expected fields %v, got %v", expected, names)
+	}
+
+	for name, o := range OptionFields(*u) {
+		if name != "ID" || o.(Option[int]) != Some(1) {
+			t.Errorf("Unexpected first field %s: %v", name, o)
+		}
+		break
+	}
+}
+
+func TestPresence(t *testing.T) {
+	presence := Presence(anyUser{Name: Some(""), Home: anyAddress{Zip: Some("1")}})
+	expected := map[string]bool{"ID": false, "Name": true, "Nick": false, "Home.Zip": true}
+	if !reflect.DeepEqual(presence, expected) {
+		t.Errorf("Expected presence %v, got %v", expected, presence)
+	}
+
+	// Fields sharing a pointer are each reported.
+	shared := &anyAddress{Zip: Some("2")}
+	presence = Presence(anyUser{Work: shared, Previous: shared})
+	expected = map[string]bool{"ID": false, "Name": false, "Nick": false, "Home.Zip": false, "Work.Zip": true, "Previous.Zip": true}
+	if !reflect.DeepEqual(presence, expected) {
+		t.Errorf("Expected presence %v, got %v", expected, presence)
+	}
+
+	// Pointers to options are followed, and nil ones are not present.
+	set := Some(1)
+	presence = Presence(struct {
+		Set, Unset *Option[int]
+		Double     **Option[int]
+		Strict     *NonNull[int]
+	}{Set: &set, Double: new(*Option[int]), Strict: &NonNull[int]{}})
+	expected = map[string]bool{"Set": true, "Unset": false, "Double": false, "Strict": false}
+	if !reflect.DeepEqual(presence, expected) {
+		t.Errorf("Expected presence %v, got %v", expected, presence)
+	}
+
+	if presence := Presence(3); len(presence) != 0 {
+		t.Errorf("Expected no fields for a non struct, got %v", presence)
+	}
+}
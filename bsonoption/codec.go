@@ -0,0 +1,103 @@
+// Package bsonoption encodes and decodes goption.Option with the MongoDB
+// driver's bson package.
+//
+// Empty options are encoded as null, or left out entirely for fields tagged
+// with omitempty, exactly like a nil pointer. Present options are encoded as
+// their underlying value, and through Option's IsZero method are left out by
+// omitempty when a pointer to that value would be. Codecs are registered per
+// type:
+//
+//	reg := bsonoption.NewRegistry(bsonoption.Register[int], bsonoption.Register[string])
+package bsonoption
+
+import (
+	"reflect"
+
+	"github.com/jordan-bonecutter/goption"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+)
+
+// Codec is a bsoncodec.ValueCodec for goption.Option[T].
+type Codec[T any] struct{}
+
+var _ bsoncodec.ValueCodec = Codec[int]{}
+
+// optionType returns the reflect.Type of goption.Option[T].
+func (Codec[T]) optionType() reflect.Type {
+	return reflect.TypeOf(goption.None[T]())
+}
+
+// elemType returns the reflect.Type of T.
+func (Codec[T]) elemType() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+// EncodeValue implements bsoncodec.ValueEncoder.
+func (c Codec[T]) EncodeValue(ec bsoncodec.EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
+	if !val.IsValid() || val.Type() != c.optionType() {
+		return bsoncodec.ValueEncoderError{Name: "OptionEncodeValue", Types: []reflect.Type{c.optionType()}, Received: val}
+	}
+
+	t, ok := val.Interface().(goption.Option[T]).Get()
+	if !ok {
+		return vw.WriteNull()
+	}
+
+	enc, err := ec.LookupEncoder(c.elemType())
+	if err != nil {
+		return err
+	}
+
+	return enc.EncodeValue(ec, vw, reflect.ValueOf(&t).Elem())
+}
+
+// DecodeValue implements bsoncodec.ValueDecoder.
+// Both null and undefined decode as an empty option.
+func (c Codec[T]) DecodeValue(dc bsoncodec.DecodeContext, vr bsonrw.ValueReader, val reflect.Value) error {
+	if !val.CanSet() || val.Type() != c.optionType() {
+		return bsoncodec.ValueDecoderError{Name: "OptionDecodeValue", Types: []reflect.Type{c.optionType()}, Received: val}
+	}
+
+	switch vr.Type() {
+	case bsontype.Null:
+		val.Set(reflect.ValueOf(goption.None[T]()))
+		return vr.ReadNull()
+	case bsontype.Undefined:
+		val.Set(reflect.ValueOf(goption.None[T]()))
+		return vr.ReadUndefined()
+	}
+
+	dec, err := dc.LookupDecoder(c.elemType())
+	if err != nil {
+		return err
+	}
+
+	var t T
+	if err := dec.DecodeValue(dc, vr, reflect.ValueOf(&t).Elem()); err != nil {
+		return err
+	}
+
+	val.Set(reflect.ValueOf(goption.Some(t)))
+	return nil
+}
+
+// Register registers a Codec for goption.Option[T] with reg.
+func Register[T any](reg *bsoncodec.Registry) {
+	var c Codec[T]
+	reg.RegisterTypeEncoder(c.optionType(), c)
+	reg.RegisterTypeDecoder(c.optionType(), c)
+}
+
+// NewRegistry returns a registry with the default bson codecs, along with
+// every Option codec registered by the register functions.
+func NewRegistry(register ...func(*bsoncodec.Registry)) *bsoncodec.Registry {
+	reg := bson.NewRegistry()
+	for _, r := range register {
+		r(reg)
+	}
+
+	return reg
+}
@@ -0,0 +1,197 @@
+package bsonoption
+
+import (
+	"bytes"
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jordan-bonecutter/goption"
+	"github.com/jordan-bonecutter/goption/optiontest"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+)
+
+type inner struct {
+	Name string `bson:"name"`
+}
+
+type withPointers struct {
+	Int     *int    `bson:"int"`
+	String  *string `bson:"string"`
+	Inner   *inner  `bson:"inner"`
+	Omitted *int    `bson:"omitted,omitempty"`
+}
+
+type withOptions struct {
+	Int     goption.Option[int]    `bson:"int"`
+	String  goption.Option[string] `bson:"string"`
+	Inner   goption.Option[inner]  `bson:"inner"`
+	Omitted goption.Option[int]    `bson:"omitted,omitempty"`
+}
+
+var registry = NewRegistry(Register[int], Register[string], Register[inner])
+
+func marshal(t *testing.T, reg *bsoncodec.Registry, v any) []byte {
+	t.Helper()
+
+	data, err := encode(reg, v)
+	if err != nil {
+		t.Fatalf("Failed marshalling bson: %s", err)
+	}
+	return data
+}
+
+func encode(reg *bsoncodec.Registry, v any) ([]byte, error) {
+	var buf bytes.Buffer
+	vw, err := bsonrw.NewBSONValueWriter(&buf)
+	if err != nil {
+		return nil, err
+	}
+
+	enc, err := bson.NewEncoder(vw)
+	if err != nil {
+		return nil, err
+	}
+	enc.SetRegistry(reg)
+
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func unmarshal(reg *bsoncodec.Registry, data []byte, v any) error {
+	dec, err := bson.NewDecoder(bsonrw.NewBSONDocumentReader(data))
+	if err != nil {
+		return err
+	}
+	dec.SetRegistry(reg)
+
+	return dec.Decode(v)
+}
+
+func ptr[T any](t T) *T {
+	return &t
+}
+
+func TestWireCompatible(t *testing.T) {
+	cases := []struct {
+		name     string
+		pointers withPointers
+		options  withOptions
+	}{
+		{
+			name: "none",
+		},
+		{
+			name: "some",
+			pointers: withPointers{
+				Int:     ptr(3),
+				String:  ptr("hey!"),
+				Inner:   &inner{Name: "inner"},
+				Omitted: ptr(0),
+			},
+			options: withOptions{
+				Int:     goption.Some(3),
+				String:  goption.Some("hey!"),
+				Inner:   goption.Some(inner{Name: "inner"}),
+				Omitted: goption.Some(0),
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			expected := marshal(t, bson.DefaultRegistry, c.pointers)
+			encoded := marshal(t, registry, c.options)
+			if !bytes.Equal(encoded, expected) {
+				t.Errorf("Expected %x, got %x", expected, encoded)
+			}
+
+			var decoded withOptions
+			if err := unmarshal(registry, expected, &decoded); err != nil {
+				t.Fatalf("Failed unmarshalling bson: %s", err)
+			}
+			if !reflect.DeepEqual(decoded, c.options) {
+				t.Errorf("Expected %#v, got %#v", c.options, decoded)
+			}
+		})
+	}
+}
+
+func TestOmitEmpty(t *testing.T) {
+	encoded := marshal(t, registry, withOptions{})
+
+	var raw bson.Raw = encoded
+	if _, err := raw.LookupErr("omitted"); err == nil {
+		t.Errorf("Expected omitted to be left out: %s", raw)
+	}
+	if value, err := raw.LookupErr("int"); err != nil || value.Type != bson.TypeNull {
+		t.Errorf("Expected int to be null: %s", raw)
+	}
+}
+
+func TestOmitEmptyPresent(t *testing.T) {
+	// Present options are omitted exactly when a pointer to their value is.
+	reg := NewRegistry(Register[int], Register[time.Time], Register[inner])
+	pointers := marshal(t, reg, struct {
+		Int   *int       `bson:"int,omitempty"`
+		Time  *time.Time `bson:"time,omitempty"`
+		Inner *inner     `bson:"inner,omitempty"`
+	}{ptr(0), &time.Time{}, &inner{}})
+	options := marshal(t, reg, struct {
+		Int   goption.Option[int]       `bson:"int,omitempty"`
+		Time  goption.Option[time.Time] `bson:"time,omitempty"`
+		Inner goption.Option[inner]     `bson:"inner,omitempty"`
+	}{goption.Some(0), goption.Some(time.Time{}), goption.Some(inner{})})
+	if !bytes.Equal(options, pointers) {
+		t.Errorf("Expected %s, got %s", bson.Raw(pointers), bson.Raw(options))
+	}
+}
+
+func TestDecodeNullResets(t *testing.T) {
+	encoded := marshal(t, bson.DefaultRegistry, bson.D{{Key: "int", Value: nil}})
+
+	decoded := withOptions{Int: goption.Some(3)}
+	if err := unmarshal(registry, encoded, &decoded); err != nil {
+		t.Fatalf("Failed unmarshalling bson: %s", err)
+	}
+	if decoded.Int.Ok() {
+		t.Errorf("Expected null to reset the option, got %v", decoded.Int)
+	}
+}
+
+func TestDecodeError(t *testing.T) {
+	encoded := marshal(t, bson.DefaultRegistry, bson.D{{Key: "int", Value: "abc"}})
+
+	var decoded withOptions
+	if err := unmarshal(registry, encoded, &decoded); err == nil {
+		t.Errorf("Expected an error decoding a string into an int")
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	rand := rand.New(rand.NewSource(1))
+	values := make([]withOptions, 200)
+	for i := range values {
+		values[i] = withOptions{
+			Int:     optiontest.Generate[int](rand, 10),
+			String:  optiontest.Generate[string](rand, 10),
+			Inner:   optiontest.Generate[inner](rand, 10),
+			Omitted: optiontest.Generate[int](rand, 10),
+		}
+	}
+
+	optiontest.RoundTrip(t, optiontest.Codec{
+		Name: "bson",
+		Marshal: func(v any) ([]byte, error) {
+			return encode(registry, v)
+		},
+		Unmarshal: func(data []byte, v any) error {
+			return unmarshal(registry, data, v)
+		},
+	}, values)
+}
@@ -0,0 +1,73 @@
+package cboroption
+
+import (
+	"github.com/fxamacker/cbor/v2"
+	"github.com/jordan-bonecutter/goption"
+)
+
+// Modes gives the cbor modes of an OptionWith. Its methods are called on the
+// zero value, so each OptionWith type always uses the same modes, and should
+// return modes created once rather than on every call:
+//
+//	type rfc3339 struct{}
+//
+//	var (
+//	  rfc3339Enc, _ = cbor.EncOptions{Time: cbor.TimeRFC3339}.EncMode()
+//	  rfc3339Dec, _ = cbor.DecOptions{}.DecMode()
+//	)
+//
+//	func (rfc3339) EncMode() cbor.EncMode { return rfc3339Enc }
+//	func (rfc3339) DecMode() cbor.DecMode { return rfc3339Dec }
+//
+//	type Event struct {
+//	  At cboroption.OptionWith[time.Time, rfc3339]
+//	}
+type Modes interface {
+	EncMode() cbor.EncMode
+	DecMode() cbor.DecMode
+}
+
+// OptionWith is like Option, but encodes and decodes its underlying value
+// with the modes of M, so that it matches a *T encoded with those modes.
+type OptionWith[T any, M Modes] struct {
+	goption.Option[T]
+}
+
+// WrapWith returns o as a cbor compatible Option using the modes of M.
+func WrapWith[M Modes, T any](o goption.Option[T]) OptionWith[T, M] {
+	return OptionWith[T, M]{o}
+}
+
+// SomeWith returns an OptionWith whose underlying value is present.
+func SomeWith[M Modes, T any](t T) OptionWith[T, M] {
+	return WrapWith[M](goption.Some(t))
+}
+
+// NoneWith returns an empty OptionWith.
+func NoneWith[T any, M Modes]() OptionWith[T, M] {
+	return WrapWith[M](goption.None[T]())
+}
+
+// MarshalCBOR implements cbor.Marshaler.
+func (o OptionWith[T, M]) MarshalCBOR() ([]byte, error) {
+	var modes M
+	t, ok := o.Get()
+	if !ok {
+		return modes.EncMode().Marshal(nil)
+	}
+
+	return modes.EncMode().Marshal(t)
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+// Both null and undefined decode as an empty option.
+func (o *OptionWith[T, M]) UnmarshalCBOR(data []byte) error {
+	var modes M
+	var t *T
+	if err := modes.DecMode().Unmarshal(data, &t); err != nil {
+		return err
+	}
+
+	*o = WrapWith[M](goption.FromRef(t))
+	return nil
+}
@@ -0,0 +1,85 @@
+package cboroption
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/jordan-bonecutter/goption"
+	"github.com/jordan-bonecutter/goption/internal/wiretest"
+	"github.com/jordan-bonecutter/goption/optiontest"
+)
+
+// rfc3339 encodes times as strings and decodes maps as map[string]any.
+type rfc3339 struct{}
+
+var (
+	rfc3339Enc = must(cbor.EncOptions{Time: cbor.TimeRFC3339}.EncMode())
+	rfc3339Dec = must(cbor.DecOptions{DefaultMapType: reflect.TypeFor[map[string]any]()}.DecMode())
+)
+
+func (rfc3339) EncMode() cbor.EncMode { return rfc3339Enc }
+func (rfc3339) DecMode() cbor.DecMode { return rfc3339Dec }
+
+func must[M any](mode M, err error) M {
+	if err != nil {
+		panic(err)
+	}
+
+	return mode
+}
+
+type withModes struct {
+	Int    OptionWith[int, rfc3339]
+	String OptionWith[string, rfc3339]
+	Inner  OptionWith[wiretest.Inner, rfc3339]
+	Time   OptionWith[time.Time, rfc3339]
+	Bytes  OptionWith[[]byte, rfc3339]
+	Nested OptionWith[OptionWith[int, rfc3339], rfc3339]
+}
+
+func TestWireCompatibleModes(t *testing.T) {
+	t.Parallel()
+	codec := optiontest.Codec{Name: "cbor rfc3339", Marshal: rfc3339Enc.Marshal, Unmarshal: rfc3339Dec.Unmarshal}
+	wiretest.Compatible(t, codec, SomeWith[rfc3339](0), func(p wiretest.Pointers[OptionWith[int, rfc3339]]) withModes {
+		return withModes{
+			Int:    WrapWith[rfc3339](goption.FromRef(p.Int)),
+			String: WrapWith[rfc3339](goption.FromRef(p.String)),
+			Inner:  WrapWith[rfc3339](goption.FromRef(p.Inner)),
+			Time:   WrapWith[rfc3339](goption.FromRef(p.Time)),
+			Bytes:  WrapWith[rfc3339](goption.FromRef(p.Bytes)),
+			Nested: WrapWith[rfc3339](goption.FromRef(p.Nested)),
+		}
+	})
+
+	// The default modes encode times as numbers.
+	now := time.Unix(1704164645, 0)
+	encoded, err := rfc3339Enc.Marshal(Some(now))
+	if err != nil {
+		t.Fatalf("Failed marshalling: %s", err)
+	}
+	if expected, _ := rfc3339Enc.Marshal(now); reflect.DeepEqual(encoded, expected) {
+		t.Errorf("Expected Option to ignore the mode it was marshalled with")
+	}
+}
+
+func TestDecModes(t *testing.T) {
+	t.Parallel()
+	encoded, err := cbor.Marshal(map[string]any{"a": 1})
+	if err != nil {
+		t.Fatalf("Failed marshalling: %s", err)
+	}
+
+	var decoded OptionWith[any, rfc3339]
+	if err := cbor.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("Failed unmarshalling: %s", err)
+	}
+	if _, isMap := decoded.Unwrap().(map[string]any); !isMap {
+		t.Errorf("Expected a map[string]any, got %T", decoded.Unwrap())
+	}
+
+	if o := NoneWith[int, rfc3339](); o.Ok() {
+		t.Errorf("Expected none, got %v", o)
+	}
+}
@@ -0,0 +1,58 @@
+// Package cboroption adapts goption.Option for use with
+// github.com/fxamacker/cbor/v2.
+//
+// Empty options are encoded as null, exactly like a nil pointer, so a *T field
+// can be replaced with an Option[T] without changing the encoded bytes.
+//
+// cbor doesn't pass its mode on to MarshalCBOR and UnmarshalCBOR, so Option
+// encodes its underlying value with the default modes. OptionWith uses the
+// modes of its Modes type parameter instead.
+package cboroption
+
+import (
+	"github.com/fxamacker/cbor/v2"
+	"github.com/jordan-bonecutter/goption"
+)
+
+// Option wraps goption.Option so that it implements cbor.Marshaler and
+// cbor.Unmarshaler. Every method of goption.Option is available on it.
+type Option[T any] struct {
+	goption.Option[T]
+}
+
+// Wrap returns o as a cbor compatible Option.
+func Wrap[T any](o goption.Option[T]) Option[T] {
+	return Option[T]{o}
+}
+
+// Some returns an Option whose underlying value is present.
+func Some[T any](t T) Option[T] {
+	return Wrap(goption.Some(t))
+}
+
+// None returns an empty optional value.
+func None[T any]() Option[T] {
+	return Wrap(goption.None[T]())
+}
+
+// MarshalCBOR implements cbor.Marshaler.
+func (o Option[T]) MarshalCBOR() ([]byte, error) {
+	t, ok := o.Get()
+	if !ok {
+		return cbor.Marshal(nil)
+	}
+
+	return cbor.Marshal(t)
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+// Both null and undefined decode as an empty option.
+func (o *Option[T]) UnmarshalCBOR(data []byte) error {
+	var t *T
+	if err := cbor.Unmarshal(data, &t); err != nil {
+		return err
+	}
+
+	*o = Wrap(goption.FromRef(t))
+	return nil
+}
@@ -0,0 +1,76 @@
+package cboroption
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/jordan-bonecutter/goption"
+	"github.com/jordan-bonecutter/goption/internal/wiretest"
+	"github.com/jordan-bonecutter/goption/optiontest"
+)
+
+type withOptions struct {
+	Int    Option[int]
+	String Option[string]
+	Inner  Option[wiretest.Inner]
+	Time   Option[time.Time]
+	Bytes  Option[[]byte]
+	Nested Option[Option[int]]
+}
+
+var codec = optiontest.Codec{
+	Name:      "cbor",
+	Marshal:   cbor.Marshal,
+	Unmarshal: cbor.Unmarshal,
+}
+
+func TestWireCompatible(t *testing.T) {
+	wiretest.Compatible(t, codec, Some(0), func(p wiretest.Pointers[Option[int]]) withOptions {
+		return withOptions{
+			Int:    Wrap(goption.FromRef(p.Int)),
+			String: Wrap(goption.FromRef(p.String)),
+			Inner:  Wrap(goption.FromRef(p.Inner)),
+			Time:   Wrap(goption.FromRef(p.Time)),
+			Bytes:  Wrap(goption.FromRef(p.Bytes)),
+			Nested: Wrap(goption.FromRef(p.Nested)),
+		}
+	})
+}
+
+func TestDecodeError(t *testing.T) {
+	encoded, err := cbor.Marshal(map[string]any{"Int": "abc"})
+	if err != nil {
+		t.Fatalf("Failed marshalling: %s", err)
+	}
+
+	var decoded withOptions
+	if err := cbor.Unmarshal(encoded, &decoded); err == nil {
+		t.Errorf("Expected an error decoding a string into an int")
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	rand := rand.New(rand.NewSource(1))
+	values := make([]withOptions, 200)
+	for i := range values {
+		v := withOptions{
+			Int:    Wrap(optiontest.Generate[int](rand, 10)),
+			String: Wrap(optiontest.Generate[string](rand, 10)),
+			Inner:  Wrap(optiontest.Generate[wiretest.Inner](rand, 10)),
+			Bytes:  Wrap(optiontest.Generate[[]byte](rand, 10)),
+		}
+		// CBOR encodes times as whole seconds by default.
+		if rand.Intn(2) == 0 {
+			v.Time = Some(time.Unix(rand.Int63n(1<<35), 0))
+		}
+		// Some(None) is encoded as None, so only nest present values.
+		if nested := optiontest.Generate[int](rand, 10); nested.Ok() {
+			v.Nested = Some(Wrap(nested))
+		}
+		values[i] = v
+	}
+
+	optiontest.RoundTrip(t, codec, values)
+}
@@ -0,0 +1,177 @@
+// protoc-gen-go-option is a protoc plugin which generates goption.Option
+// accessors for the optional fields of messages generated by protoc-gen-go.
+//
+// For every proto3 optional field, and every field holding a well known
+// wrapper type such as google.protobuf.Int32Value, it generates:
+//
+//	func (x *Msg) GetXOption() goption.Option[int32]
+//	func (x *Msg) SetXOption(o goption.Option[int32])
+//
+// The accessors are written to a _option.pb.go file next to the .pb.go file.
+// Run it alongside protoc-gen-go:
+//
+//	protoc --go_out=. --go-option_out=. example.proto
+package main
+
+import (
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+const (
+	goptionPackage     = protogen.GoImportPath("github.com/jordan-bonecutter/goption")
+	protooptionPackage = protogen.GoImportPath("github.com/jordan-bonecutter/goption/protooption")
+	wrapperspbPackage  = protogen.GoImportPath("google.golang.org/protobuf/types/known/wrapperspb")
+)
+
+// wrapperConstructors maps each well known wrapper message to its wrapperspb
+// constructor.
+var wrapperConstructors = map[protoreflect.FullName]string{
+	"google.protobuf.DoubleValue": "Double",
+	"google.protobuf.FloatValue":  "Float",
+	"google.protobuf.Int64Value":  "Int64",
+	"google.protobuf.UInt64Value": "UInt64",
+	"google.protobuf.Int32Value":  "Int32",
+	"google.protobuf.UInt32Value": "UInt32",
+	"google.protobuf.BoolValue":   "Bool",
+	"google.protobuf.StringValue": "String",
+	"google.protobuf.BytesValue":  "Bytes",
+}
+
+func main() {
+	protogen.Options{}.Run(func(gen *protogen.Plugin) error {
+		gen.SupportedFeatures = uint64(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL)
+		for _, f := range gen.Files {
+			if f.Generate {
+				generateFile(gen, f)
+			}
+		}
+		return nil
+	})
+}
+
+// generateFile generates the _option.pb.go file for f.
+// Nothing is generated if f has no optional fields.
+func generateFile(gen *protogen.Plugin, f *protogen.File) *protogen.GeneratedFile {
+	g := gen.NewGeneratedFile(f.GeneratedFilenamePrefix+"_option.pb.go", f.GoImportPath)
+	g.P("// Code generated by protoc-gen-go-option. DO NOT EDIT.")
+	g.P("// source: ", f.Desc.Path())
+	g.P()
+	g.P("package ", f.GoPackageName)
+
+	generated := false
+	for _, m := range f.Messages {
+		if generateMessage(g, m) {
+			generated = true
+		}
+	}
+
+	if !generated {
+		g.Skip()
+	}
+	return g
+}
+
+// generateMessage generates accessors for m and its nested messages.
+// It returns true if anything was generated.
+func generateMessage(g *protogen.GeneratedFile, m *protogen.Message) bool {
+	generated := false
+	if !m.Desc.IsMapEntry() {
+		for _, field := range m.Fields {
+			if generateField(g, m, field) {
+				generated = true
+			}
+		}
+	}
+
+	for _, nested := range m.Messages {
+		if generateMessage(g, nested) {
+			generated = true
+		}
+	}
+
+	return generated
+}
+
+// generateField generates the accessors for field if it is optional.
+// It returns true if anything was generated.
+func generateField(g *protogen.GeneratedFile, m *protogen.Message, field *protogen.Field) bool {
+	if field.Desc.IsList() || field.Desc.IsMap() {
+		return false
+	}
+	if field.Oneof != nil && !field.Oneof.Desc.IsSynthetic() {
+		return false
+	}
+
+	var valueType, get, set string
+	switch {
+	case field.Message != nil:
+		constructor, isWrapper := wrapperConstructors[field.Message.Desc.FullName()]
+		if !isWrapper {
+			return false
+		}
+		valueType = goType(g, field.Message.Fields[0])
+		get = g.QualifiedGoIdent(protooptionPackage.Ident("FromWrapper")) + "(x.Get" + field.GoName + "())"
+		set = g.QualifiedGoIdent(protooptionPackage.Ident("ToWrapper")) + "(o, " + g.QualifiedGoIdent(wrapperspbPackage.Ident(constructor)) + ")"
+
+	case !field.Desc.HasPresence():
+		return false
+
+	case field.Desc.Kind() == protoreflect.BytesKind:
+		valueType = "[]byte"
+		get = g.QualifiedGoIdent(protooptionPackage.Ident("FromBytes")) + "(x." + field.GoName + ")"
+		set = g.QualifiedGoIdent(protooptionPackage.Ident("ToBytes")) + "(o)"
+
+	default:
+		valueType = goType(g, field)
+		get = g.QualifiedGoIdent(protooptionPackage.Ident("FromPointer")) + "(x." + field.GoName + ")"
+		set = g.QualifiedGoIdent(protooptionPackage.Ident("ToPointer")) + "(o)"
+	}
+
+	option := g.QualifiedGoIdent(goptionPackage.Ident("Option")) + "[" + valueType + "]"
+	none := g.QualifiedGoIdent(goptionPackage.Ident("None")) + "[" + valueType + "]()"
+
+	g.P()
+	g.P("// Get", field.GoName, "Option returns the ", field.Desc.Name(), " field as an Option.")
+	g.P("func (x *", m.GoIdent, ") Get", field.GoName, "Option() ", option, " {")
+	g.P("if x == nil {")
+	g.P("return ", none)
+	g.P("}")
+	g.P("return ", get)
+	g.P("}")
+	g.P()
+	g.P("// Set", field.GoName, "Option sets the ", field.Desc.Name(), " field from an Option.")
+	g.P("func (x *", m.GoIdent, ") Set", field.GoName, "Option(o ", option, ") {")
+	g.P("x.", field.GoName, " = ", set)
+	g.P("}")
+	return true
+}
+
+// goType returns the Go type used for the scalar field.
+func goType(g *protogen.GeneratedFile, field *protogen.Field) string {
+	switch field.Desc.Kind() {
+	case protoreflect.BoolKind:
+		return "bool"
+	case protoreflect.EnumKind:
+		return g.QualifiedGoIdent(field.Enum.GoIdent)
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return "int32"
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return "uint32"
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return "int64"
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return "uint64"
+	case protoreflect.FloatKind:
+		return "float32"
+	case protoreflect.DoubleKind:
+		return "float64"
+	case protoreflect.StringKind:
+		return "string"
+	case protoreflect.BytesKind:
+		return "[]byte"
+	}
+
+	panic("protoc-gen-go-option: unexpected field kind " + field.Desc.Kind().String())
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"os"
+	"testing"
+
+	"github.com/jordan-bonecutter/goption/internal/testpb"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+// generate runs the plugin over the testpb example.proto.
+func generate(t *testing.T) *pluginpb.CodeGeneratorResponse {
+	t.Helper()
+
+	req := &pluginpb.CodeGeneratorRequest{
+		FileToGenerate: []string{"example.proto"},
+		Parameter:      proto.String("paths=source_relative"),
+		ProtoFile: []*descriptorpb.FileDescriptorProto{
+			protodesc.ToFileDescriptorProto(wrapperspb.File_google_protobuf_wrappers_proto),
+			protodesc.ToFileDescriptorProto(testpb.File_example_proto),
+		},
+	}
+
+	gen, err := protogen.Options{}.New(req)
+	if err != nil {
+		t.Fatalf("Failed creating plugin: %s", err)
+	}
+
+	for _, f := range gen.Files {
+		if f.Generate {
+			generateFile(gen, f)
+		}
+	}
+
+	resp := gen.Response()
+	if resp.Error != nil {
+		t.Fatalf("Failed generating: %s", resp.GetError())
+	}
+	return resp
+}
+
+func TestGenerateGolden(t *testing.T) {
+	expected, err := os.ReadFile("../../internal/testpb/example_option.pb.go")
+	if err != nil {
+		t.Fatalf("Failed reading golden file: %s", err)
+	}
+
+	resp := generate(t)
+	if len(resp.File) != 1 {
+		t.Fatalf("Expected one generated file, got %d", len(resp.File))
+	}
+
+	if name := resp.File[0].GetName(); name != "example_option.pb.go" {
+		t.Errorf("Unexpected generated file name: %s", name)
+	}
+	if content := resp.File[0].GetContent(); content != string(expected) {
+		t.Errorf("Generated code doesn't match golden file:\n%s", content)
+	}
+}
+
+func TestGenerateSkipsFilesWithoutOptionals(t *testing.T) {
+	req := &pluginpb.CodeGeneratorRequest{
+		FileToGenerate: []string{"google/protobuf/wrappers.proto"},
+		ProtoFile: []*descriptorpb.FileDescriptorProto{
+			protodesc.ToFileDescriptorProto(wrapperspb.File_google_protobuf_wrappers_proto),
+		},
+	}
+
+	gen, err := protogen.Options{}.New(req)
+	if err != nil {
+		t.Fatalf("Failed creating plugin: %s", err)
+	}
+
+	for _, f := range gen.Files {
+		if f.Generate {
+			generateFile(gen, f)
+		}
+	}
+
+	if files := gen.Response().File; len(files) != 0 {
+		t.Errorf("Expected nothing to be generated, got %d files", len(files))
+	}
+}
@@ -0,0 +1,80 @@
+package goption
+
+import (
+	"cmp"
+)
+
+// Compare returns -1 if a is less than b, 0 if they are equal and +1 if a is
+// greater than b. Empty optional values sort before present ones, matching
+// SQL's NULLS FIRST. Present values are ordered by cmp.Compare.
+func Compare[T cmp.Ordered](a, b Option[T]) int {
+	return CompareFunc(a, b, cmp.Compare[T])
+}
+
+// CompareNoneLast is like Compare but empty optional values sort after
+// present ones, matching SQL's NULLS LAST.
+func CompareNoneLast[T cmp.Ordered](a, b Option[T]) int {
+	return CompareFuncNoneLast(a, b, cmp.Compare[T])
+}
+
+// CompareFunc is like Compare but orders present values using f.
+// The position of empty values does not depend on f, so passing a reversed f
+// behaves like SQL's ORDER BY ... DESC NULLS FIRST.
+func CompareFunc[T any](a, b Option[T], f func(T, T) int) int {
+	switch {
+	case !a.ok && !b.ok:
+		return 0
+	case !a.ok:
+		return -1
+	case !b.ok:
+		return +1
+	}
+
+	return f(a.t, b.t)
+}
+
+// CompareFuncNoneLast is like CompareFunc but empty optional values sort after
+// present ones, matching SQL's NULLS LAST.
+func CompareFuncNoneLast[T any](a, b Option[T], f func(T, T) int) int {
+	switch {
+	case !a.ok && !b.ok:
+		return 0
+	case !a.ok:
+		return +1
+	case !b.ok:
+		return -1
+	}
+
+	return f(a.t, b.t)
+}
+
+// CompareBy returns a comparison function for E ordered by the optional key
+// returned from key, for use with slices.SortFunc. Empty keys sort first.
+func CompareBy[E any, T cmp.Ordered](key func(E) Option[T]) func(E, E) int {
+	return func(a, b E) int {
+		return Compare(key(a), key(b))
+	}
+}
+
+// CompareByNoneLast is like CompareBy but empty keys sort last.
+func CompareByNoneLast[E any, T cmp.Ordered](key func(E) Option[T]) func(E, E) int {
+	return func(a, b E) int {
+		return CompareNoneLast(key(a), key(b))
+	}
+}
+
+// Equal returns true if a and b are both empty, or both present with equal values.
+func Equal[T comparable](a, b Option[T]) bool {
+	return EqualFunc(a, b, func(x, y T) bool {
+		return x == y
+	})
+}
+
+// EqualFunc is like Equal but compares present values using f.
+func EqualFunc[T any](a, b Option[T], f func(T, T) bool) bool {
+	if !a.ok || !b.ok {
+		return a.ok == b.ok
+	}
+
+	return f(a.t, b.t)
+}
@@ -0,0 +1,126 @@
+package goption
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	cases := []struct {
+		a, b                Option[int]
+		noneFirst, noneLast int
+	}{
+		{None[int](), None[int](), 0, 0},
+		{None[int](), Some(1), -1, +1},
+		{Some(1), None[int](), +1, -1},
+		{Some(1), Some(2), -1, -1},
+		{Some(2), Some(1), +1, +1},
+		{Some(2), Some(2), 0, 0},
+	}
+
+	for _, c := range cases {
+		if got := Compare(c.a, c.b); got != c.noneFirst {
+			t.Errorf("Compare(%v, %v): expected %d, got %d", c.a, c.b, c.noneFirst, got)
+		}
+		if got := CompareNoneLast(c.a, c.b); got != c.noneLast {
+			t.Errorf("CompareNoneLast(%v, %v): expected %d, got %d", c.a, c.b, c.noneLast, got)
+		}
+	}
+}
+
+func TestCompareFuncDescending(t *testing.T) {
+	desc := func(a, b int) int {
+		return cmp.Compare(b, a)
+	}
+
+	values := []Option[int]{Some(1), None[int](), Some(3), Some(2)}
+	slices.SortFunc(values, func(a, b Option[int]) int {
+		return CompareFuncNoneLast(a, b, desc)
+	})
+
+	// ORDER BY value DESC NULLS LAST
+	expected := []Option[int]{Some(3), Some(2), Some(1), None[int]()}
+	if !slices.EqualFunc(values, expected, Equal[int]) {
+		t.Errorf("Expected %v, got %v", expected, values)
+	}
+
+	slices.SortFunc(values, func(a, b Option[int]) int {
+		return CompareFunc(a, b, desc)
+	})
+
+	// ORDER BY value DESC NULLS FIRST
+	expected = []Option[int]{None[int](), Some(3), Some(2), Some(1)}
+	if !slices.EqualFunc(values, expected, Equal[int]) {
+		t.Errorf("Expected %v, got %v", expected, values)
+	}
+}
+
+func TestCompareBy(t *testing.T) {
+	type record struct {
+		name string
+		age  Option[int]
+	}
+
+	records := []record{
+		{"a", Some(30)},
+		{"b", None[int]()},
+		{"c", Some(20)},
+	}
+	age := func(r record) Option[int] {
+		return r.age
+	}
+	names := func() string {
+		var sb strings.Builder
+		for _, r := range records {
+			sb.WriteString(r.name)
+		}
+		return sb.String()
+	}
+
+	slices.SortFunc(records, CompareBy(age))
+	if got := names(); got != "bca" {
+		t.Errorf("Expected bca, got %s", got)
+	}
+
+	slices.SortFunc(records, CompareByNoneLast(age))
+	if got := names(); got != "cab" {
+		t.Errorf("Expected cab, got %s", got)
+	}
+}
+
+func TestEqual(t *testing.T) {
+	if !Equal(None[int](), None[int]()) {
+		t.Errorf("Expected none to equal none")
+	}
+	if Equal(None[int](), Some(0)) || Equal(Some(0), None[int]()) {
+		t.Errorf("Expected none not to equal some")
+	}
+	if !Equal(Some(1), Some(1)) {
+		t.Errorf("Expected equal values to be equal")
+	}
+	if Equal(Some(1), Some(2)) {
+		t.Errorf("Expected different values not to be equal")
+	}
+
+	// An unmarshalled null keeps its old value but must still equal none.
+	opt := Some(1)
+	if err := opt.UnmarshalJSON([]byte("null")); err != nil {
+		t.Fatalf("Failed unmarshalling null: %s", err)
+	}
+	if !Equal(opt, None[int]()) {
+		t.Errorf("Expected unmarshalled null to equal none")
+	}
+}
+
+func TestEqualFunc(t *testing.T) {
+	a := Some([]int{1, 2})
+	b := Some([]int{1, 2})
+	if !EqualFunc(a, b, slices.Equal[[]int]) {
+		t.Errorf("Expected equal slices to be equal")
+	}
+	if EqualFunc(a, None[[]int](), slices.Equal[[]int]) {
+		t.Errorf("Expected some not to equal none")
+	}
+}
@@ -0,0 +1,251 @@
+// Package config loads a struct of goption.Option fields from several
+// sources, such as files, environment variables and flags, and remembers
+// where each value came from.
+//
+//	type Config struct {
+//	  DB struct {
+//	    Host goption.Option[string] `config:"host,required"`
+//	    Port goption.Option[int]    `config:"port"`
+//	  } `config:"db"`
+//	  Debug goption.Option[bool] `config:"debug"`
+//	}
+//
+//	var cfg Config
+//	cfg.DB.Port = goption.Some(5432) // a default
+//	sources, err := config.Load(&cfg,
+//	  config.File("config.yaml"),
+//	  config.Env("APP_"),
+//	  config.Flags(flag.NewFlagSet("app", flag.ExitOnError), os.Args[1:]),
+//	)
+//	sources.Source("db.port") // "default", "file:config.yaml", "env:APP_DB_PORT" or "flag:db.port"
+//
+// Sources are given in increasing priority, and a present value from a later
+// source replaces the value from an earlier one, as with goption.Merge.
+//
+// Each field has a dotted key made of the names of the structs it is nested
+// in and its own name. A name is taken from the config tag, then the json
+// tag, and otherwise is the field name in lower case. Fields named "-" are
+// ignored. Adding ",required" to the config tag makes Load fail if no source
+// sets the field.
+package config
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/jordan-bonecutter/goption"
+	"github.com/jordan-bonecutter/goption/reflectopt"
+)
+
+// Field describes an Option field of a configuration struct.
+type Field struct {
+	// Key is the dotted key of the field, such as "db.port".
+	Key string
+
+	// Type is the type of the value held by the Option.
+	Type reflect.Type
+
+	// Required is true if the field must be set by a source.
+	Required bool
+
+	index []int
+}
+
+// A Source provides values for the fields of a configuration struct.
+type Source interface {
+	// Load returns the values it has for fields, keyed by Field.Key.
+	Load(fields []Field) (map[string]Value, error)
+}
+
+// Value is a value found by a Source, either as text such as an environment
+// variable or as JSON.
+type Value struct {
+	// Origin describes where the value came from, such as "env:DB_PORT".
+	Origin string
+
+	data   []byte
+	isJSON bool
+}
+
+// Text returns a Value holding text, which is decoded like
+// goption.Option.UnmarshalText. Strings are taken as is, without quotes.
+func Text(origin, text string) Value {
+	return Value{Origin: origin, data: []byte(text)}
+}
+
+// JSON returns a Value holding JSON, which is decoded like
+// goption.Option.UnmarshalJSON.
+func JSON(origin string, data []byte) Value {
+	return Value{Origin: origin, data: data, isJSON: true}
+}
+
+// MissingError is returned by Load when required fields are not set.
+type MissingError struct {
+	Keys []string
+}
+
+// Error implements error.
+func (e *MissingError) Error() string {
+	return "config: missing required " + strings.Join(e.Keys, ", ")
+}
+
+// Sources records where the values of a loaded configuration came from.
+type Sources struct {
+	origins map[string]string
+}
+
+// Source returns the origin of the value of the field with the given key,
+// such as "env:DB_PORT". It returns "default" for values which were set
+// before loading, and "" for fields which are not set.
+func (s *Sources) Source(key string) string {
+	return s.origins[key]
+}
+
+// Load fills the struct dst points to from sources, in increasing priority,
+// and returns where each value came from. Present fields of dst are kept
+// unless a source replaces them.
+//
+// Values which can't be decoded and missing required fields are reported
+// together in one error, joined with errors.Join, alongside which the
+// successfully loaded values are still set. A Source which fails to load
+// stops Load immediately.
+//
+// Load panics if dst is not a pointer to a struct.
+func Load(dst any, sources ...Source) (*Sources, error) {
+	d := reflect.ValueOf(dst)
+	if d.Kind() != reflect.Pointer || d.IsNil() || d.Elem().Kind() != reflect.Struct {
+		panic(fmt.Sprintf("config: Load into %T, want a pointer to a struct", dst))
+	}
+
+	t := d.Elem().Type()
+	fields := Fields(t)
+	origins := map[string]string{}
+	for _, field := range fields {
+		if reflectopt.IsSome(d.Elem().FieldByIndex(field.index)) {
+			origins[field.Key] = "default"
+		}
+	}
+
+	var errs []error
+	for _, source := range sources {
+		values, err := source.Load(fields)
+		if err != nil {
+			return &Sources{origins: origins}, err
+		}
+
+		layer := reflect.New(t)
+		for _, field := range fields {
+			value, found := values[field.Key]
+			if !found {
+				continue
+			}
+
+			opt := layer.Elem().FieldByIndex(field.index)
+			if err := decode(opt, field.Type, value); err != nil {
+				// A failed decode may leave the option present, which
+				// would replace a value from an earlier source.
+				reflectopt.SetNone(opt)
+				errs = append(errs, fmt.Errorf("config: %s from %s: %w", field.Key, value.Origin, err))
+				continue
+			}
+			if reflectopt.IsSome(opt) {
+				origins[field.Key] = value.Origin
+			}
+		}
+
+		goption.Merge(dst, layer.Interface())
+	}
+
+	missing := &MissingError{}
+	for _, field := range fields {
+		if field.Required && !reflectopt.IsSome(d.Elem().FieldByIndex(field.index)) {
+			missing.Keys = append(missing.Keys, field.Key)
+		}
+	}
+	if len(missing.Keys) > 0 {
+		errs = append(errs, missing)
+	}
+
+	return &Sources{origins: origins}, errors.Join(errs...)
+}
+
+// Fields returns the Option fields of the struct type t, in the order they
+// are declared. Nested structs are walked, and the fields of embedded structs
+// are keyed as if they were declared in the outer struct.
+func Fields(t reflect.Type) []Field {
+	return appendFields(nil, t, "", nil)
+}
+
+func appendFields(fields []Field, t reflect.Type, prefix string, index []int) []Field {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() && !field.Anonymous {
+			continue
+		}
+
+		name, opts := fieldName(field)
+		if name == "-" {
+			continue
+		}
+
+		fieldIndex := append(index[:len(index):len(index)], i)
+		switch {
+		case reflectopt.IsOption(field.Type):
+			fields = append(fields, Field{
+				Key:      prefix + name,
+				Type:     reflectopt.ElemType(field.Type),
+				Required: opts == "required",
+				index:    fieldIndex,
+			})
+		case field.Type.Kind() != reflect.Struct:
+		case field.Anonymous && !hasTagName(field):
+			fields = appendFields(fields, field.Type, prefix, fieldIndex)
+		default:
+			fields = appendFields(fields, field.Type, prefix+name+".", fieldIndex)
+		}
+	}
+
+	return fields
+}
+
+// fieldName returns the name of field in its key, or "-" if it's ignored, and
+// its config tag options.
+func fieldName(field reflect.StructField) (string, string) {
+	name, opts, _ := strings.Cut(field.Tag.Get("config"), ",")
+	if name == "" {
+		name, _, _ = strings.Cut(field.Tag.Get("json"), ",")
+	}
+	if name == "" {
+		name = strings.ToLower(field.Name)
+	}
+
+	return name, opts
+}
+
+// hasTagName returns true if field is given a name by a tag.
+func hasTagName(field reflect.StructField) bool {
+	configName, _, _ := strings.Cut(field.Tag.Get("config"), ",")
+	jsonName, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	return configName != "" || (jsonName != "" && jsonName != "-")
+}
+
+var textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+
+// decode decodes value into the option opt, which holds an elem.
+func decode(opt reflect.Value, elem reflect.Type, value Value) error {
+	data := value.data
+	if !value.isJSON {
+		if elem.Kind() != reflect.String || reflect.PointerTo(elem).Implements(textUnmarshalerType) {
+			return opt.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText(data)
+		}
+
+		// Quote strings so that they're not parsed as JSON.
+		data, _ = json.Marshal(string(data))
+	}
+
+	return opt.Addr().Interface().(json.Unmarshaler).UnmarshalJSON(data)
+}
@@ -0,0 +1,237 @@
+package config
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jordan-bonecutter/goption"
+)
+
+type dbConfig struct {
+	Host goption.Option[string] `config:"host,required"`
+	Port goption.Option[int]    `config:"port"`
+}
+
+type logConfig struct {
+	Level goption.Option[string] `json:"level"`
+}
+
+type testConfig struct {
+	logConfig
+	DB      dbConfig                 `config:"db"`
+	Name    goption.Option[string]   `config:"name,required"`
+	Debug   goption.Option[bool]     `config:"debug"`
+	Timeout goption.Option[duration] `config:"timeout"`
+	Tags    goption.Option[[]string]
+	Strict  goption.NonNull[int] `config:"strict"`
+	Version string
+	Secret  goption.Option[string] `json:"-"`
+	Ignored goption.Option[string] `config:"-" json:"ignored"`
+}
+
+// duration is a time.Duration which is unmarshalled from text such as "1s".
+type duration time.Duration
+
+func (d *duration) UnmarshalText(data []byte) error {
+	parsed, err := time.ParseDuration(string(data))
+	*d = duration(parsed)
+	return err
+}
+
+func (d *duration) UnmarshalJSON(data []byte) error {
+	return d.UnmarshalText([]byte(strings.Trim(string(data), `"`)))
+}
+
+// values returns a Source with the given values, whose origin is name
+// followed by their key.
+func values(name string, values map[string]string) Source {
+	return SourceFunc(func(fields []Field) (map[string]Value, error) {
+		found := map[string]Value{}
+		for key, text := range values {
+			found[key] = Text(name+":"+key, text)
+		}
+		return found, nil
+	})
+}
+
+func TestFields(t *testing.T) {
+	var keys []string
+	for _, field := range Fields(reflect.TypeFor[testConfig]()) {
+		keys = append(keys, field.Key)
+		if field.Required != (field.Key == "name" || field.Key == "db.host") {
+			t.Errorf("Unexpected Required for %s", field.Key)
+		}
+	}
+
+	expected := []string{"level", "db.host", "db.port", "name", "debug", "timeout", "tags", "strict"}
+	if !reflect.DeepEqual(keys, expected) {
+		t.Errorf("Expected keys %v, got %v", expected, keys)
+	}
+
+	if typ := Fields(reflect.TypeFor[testConfig]())[2].Type; typ != reflect.TypeFor[int]() {
+		t.Errorf("Expected db.port to hold an int, got %v", typ)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	cfg := testConfig{Debug: goption.Some(true)}
+	cfg.DB.Port = goption.Some(5432)
+
+	sources, err := Load(&cfg,
+		values("low", map[string]string{"db.host": "low", "db.port": "1", "name": "app", "level": "info"}),
+		// An explicit null doesn't replace a lower priority value, but strings
+		// are taken as is.
+		values("high", map[string]string{"db.port": "2", "timeout": "1s", "tags": `["a","b"]`, "debug": "null", "name": "null"}),
+	)
+	if err != nil {
+		t.Fatalf("Failed loading: %s", err)
+	}
+
+	expected := testConfig{
+		logConfig: logConfig{Level: goption.Some("info")},
+		DB:        dbConfig{Host: goption.Some("low"), Port: goption.Some(2)},
+		Name:      goption.Some("null"),
+		Debug:     goption.Some(true),
+		Timeout:   goption.Some(duration(time.Second)),
+		Tags:      goption.Some([]string{"a", "b"}),
+	}
+	if !reflect.DeepEqual(cfg, expected) {
+		t.Errorf("Unexpected config:\n%+v\nwant\n%+v", cfg, expected)
+	}
+
+	for key, origin := range map[string]string{
+		"db.host": "low:db.host",
+		"db.port": "high:db.port",
+		"level":   "low:level",
+		"debug":   "default",
+		"strict":  "",
+		"unknown": "",
+	} {
+		if got := sources.Source(key); got != origin {
+			t.Errorf("Expected %s to come from %q, got %q", key, origin, got)
+		}
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	var cfg testConfig
+	sources, err := Load(&cfg,
+		values("env", map[string]string{"db.port": "abc", "debug": "yes", "db.host": "h"}),
+		values("flag", map[string]string{"db.port": "3"}),
+	)
+	if err == nil {
+		t.Fatalf("Expected an error")
+	}
+
+	for _, want := range []string{
+		"config: db.port from env:db.port: ",
+		"config: debug from env:debug: ",
+		"config: missing required name",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected %q in the error:\n%s", want, err)
+		}
+	}
+
+	var missing *MissingError
+	if !errors.As(err, &missing) || !reflect.DeepEqual(missing.Keys, []string{"name"}) {
+		t.Errorf("Expected a *MissingError for name, got %v", missing)
+	}
+
+	// Values from other sources are still loaded, and the ones which failed
+	// are left unset.
+	if cfg.DB.Port != goption.Some(3) || sources.Source("db.port") != "flag:db.port" {
+		t.Errorf("Expected db.port to be loaded, got %v", cfg.DB.Port)
+	}
+	if cfg.Debug.Ok() || sources.Source("debug") != "" {
+		t.Errorf("Expected debug to be unset, got %v", cfg.Debug)
+	}
+}
+
+func TestLoadErrorKeepsEarlierValues(t *testing.T) {
+	cfg := testConfig{Debug: goption.Some(true)}
+	cfg.DB.Port = goption.Some(5432)
+	sources, err := Load(&cfg,
+		values("file", map[string]string{"name": "app", "db.host": "h"}),
+		values("env", map[string]string{"db.port": "abc", "debug": "yes"}),
+	)
+	if err == nil {
+		t.Fatalf("Expected an error")
+	}
+
+	if cfg.DB.Port != goption.Some(5432) || sources.Source("db.port") != "default" {
+		t.Errorf("Expected the default db.port to be kept, got %v from %q", cfg.DB.Port, sources.Source("db.port"))
+	}
+	if cfg.Debug != goption.Some(true) || sources.Source("debug") != "default" {
+		t.Errorf("Expected the default debug to be kept, got %v from %q", cfg.Debug, sources.Source("debug"))
+	}
+	if cfg.Name != goption.Some("app") {
+		t.Errorf("Expected name to be loaded, got %v", cfg.Name)
+	}
+
+	var missing *MissingError
+	if errors.As(err, &missing) {
+		t.Errorf("Unexpected missing keys %v", missing.Keys)
+	}
+
+	// A required field whose only value fails to decode is still missing.
+	type required struct {
+		Port goption.Option[int] `config:"port,required"`
+	}
+	var r required
+	_, err = Load(&r, values("env", map[string]string{"port": "abc"}))
+	if !errors.As(err, &missing) || !reflect.DeepEqual(missing.Keys, []string{"port"}) {
+		t.Errorf("Expected port to be missing, got %v", err)
+	}
+	if r.Port.Ok() {
+		t.Errorf("Expected port to be unset, got %v", r.Port)
+	}
+}
+
+func TestLoadMissing(t *testing.T) {
+	var cfg testConfig
+	_, err := Load(&cfg)
+	if err == nil || err.Error() != "config: missing required db.host, name" {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestLoadSourceError(t *testing.T) {
+	failed := errors.New("failed")
+	var cfg testConfig
+	_, err := Load(&cfg, SourceFunc(func([]Field) (map[string]Value, error) {
+		return nil, failed
+	}))
+	if err != failed {
+		t.Errorf("Expected the source's error, got %v", err)
+	}
+}
+
+func TestLoadJSONValue(t *testing.T) {
+	var cfg testConfig
+	_, err := Load(&cfg, SourceFunc(func([]Field) (map[string]Value, error) {
+		return map[string]Value{
+			"name":    JSON("json", []byte(`"app"`)),
+			"db.host": JSON("json", []byte(`"h"`)),
+			"strict":  JSON("json", []byte(`null`)),
+		}, nil
+	}))
+	if err == nil || !strings.Contains(err.Error(), "config: strict from json: ") {
+		t.Errorf("Expected null to be rejected for strict, got %v", err)
+	}
+	if cfg.Name != goption.Some("app") {
+		t.Errorf("Expected name to be loaded, got %v", cfg.Name)
+	}
+}
+
+func TestLoadPanics(t *testing.T) {
+	defer func() {
+		if r := recover(); r != "config: Load into config.testConfig, want a pointer to a struct" {
+			t.Errorf("Unexpected panic: %v", r)
+		}
+	}()
+	Load(testConfig{})
+}
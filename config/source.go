@@ -0,0 +1,158 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SourceFunc is a Source implemented by a function.
+type SourceFunc func(fields []Field) (map[string]Value, error)
+
+// Load implements Source.
+func (f SourceFunc) Load(fields []Field) (map[string]Value, error) {
+	return f(fields)
+}
+
+// File returns a Source reading the JSON or YAML file at path, depending on
+// whether its extension is .json, or .yaml or .yml. Objects in the file
+// nest like the keys, so "db.port" is read from {"db": {"port": 5432}}.
+// The origin of its values is "file:" followed by path.
+func File(path string) Source {
+	return SourceFunc(func(fields []Field) (map[string]Value, error) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("config: %w", err)
+		}
+
+		var doc any
+		switch ext := filepath.Ext(path); ext {
+		case ".json":
+			dec := json.NewDecoder(bytes.NewReader(data))
+			dec.UseNumber()
+			err = dec.Decode(&doc)
+		case ".yaml", ".yml":
+			err = yaml.Unmarshal(data, &doc)
+		default:
+			return nil, fmt.Errorf("config: unknown file extension %q for %s", ext, path)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("config: parsing %s: %w", path, err)
+		}
+
+		return documentValues(doc, fields, "file:"+path)
+	})
+}
+
+// documentValues returns the values in the decoded document doc for fields.
+func documentValues(doc any, fields []Field, origin string) (map[string]Value, error) {
+	values := map[string]Value{}
+	for _, field := range fields {
+		v, found := lookup(doc, strings.Split(field.Key, "."))
+		if !found {
+			continue
+		}
+
+		data, err := json.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("config: %s in %s: %w", field.Key, origin, err)
+		}
+		values[field.Key] = JSON(origin, data)
+	}
+
+	return values, nil
+}
+
+// lookup returns the value at path in the decoded document doc.
+func lookup(doc any, path []string) (any, bool) {
+	for _, name := range path {
+		object, isObject := doc.(map[string]any)
+		if !isObject {
+			return nil, false
+		}
+
+		var found bool
+		if doc, found = object[name]; !found {
+			return nil, false
+		}
+	}
+
+	return doc, true
+}
+
+// Env returns a Source reading environment variables. The variable for a
+// field is its key in upper case, with dots and dashes replaced by
+// underscores, following prefix. With the prefix "APP_", "db.port" is read
+// from APP_DB_PORT and has the origin "env:APP_DB_PORT".
+func Env(prefix string) Source {
+	return SourceFunc(func(fields []Field) (map[string]Value, error) {
+		values := map[string]Value{}
+		for _, field := range fields {
+			name := prefix + strings.ToUpper(strings.NewReplacer(".", "_", "-", "_").Replace(field.Key))
+			if text, found := os.LookupEnv(name); found {
+				values[field.Key] = Text("env:"+name, text)
+			}
+		}
+
+		return values, nil
+	})
+}
+
+// Flags returns a Source which defines a flag named by the key of every
+// field in fs and parses args with it. Only flags which appear in args are
+// used, and "db.port" has the origin "flag:db.port". Fields holding a bool
+// can be set with -name alone.
+//
+// The flags are defined when loading. Loading again with the same fs reuses
+// them, and fields whose key is already the name of another flag in fs are
+// skipped, so it's best to give Flags its own flag.FlagSet.
+func Flags(fs *flag.FlagSet, args []string) Source {
+	return SourceFunc(func(fields []Field) (map[string]Value, error) {
+		values := map[string]Value{}
+		for _, field := range fields {
+			set := func(text string) {
+				values[field.Key] = Text("flag:"+field.Key, text)
+			}
+			if defined := fs.Lookup(field.Key); defined != nil {
+				if value, isField := defined.Value.(*flagValue); isField {
+					value.set = set
+				}
+				continue
+			}
+
+			fs.Var(&flagValue{isBool: field.Type.Kind() == reflect.Bool, set: set}, field.Key, "sets "+field.Key)
+		}
+
+		if err := fs.Parse(args); err != nil {
+			return nil, fmt.Errorf("config: %w", err)
+		}
+
+		return values, nil
+	})
+}
+
+// flagValue is a flag.Value which records the text it is set to.
+type flagValue struct {
+	isBool bool
+	set    func(string)
+}
+
+func (f *flagValue) String() string {
+	return ""
+}
+
+func (f *flagValue) Set(text string) error {
+	f.set(text)
+	return nil
+}
+
+func (f *flagValue) IsBoolFlag() bool {
+	return f.isBool
+}
@@ -0,0 +1,187 @@
+package config
+
+import (
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jordan-bonecutter/goption"
+)
+
+// writeFile writes data to name in a temporary directory and returns its path.
+func writeFile(t *testing.T, name, data string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatalf("Failed writing %s: %s", name, err)
+	}
+
+	return path
+}
+
+func TestFile(t *testing.T) {
+	for name, data := range map[string]string{
+		"config.json": `{"db": {"host": "db", "port": 5432}, "name": "app", "tags": ["a"], "level": null, "debug": true}`,
+		"config.yaml": "db:\n  host: db\n  port: 5432\nname: app\ntags: [a]\nlevel: ~\ndebug: true\n",
+		"config.yml":  "db: {host: db, port: 5432}\nname: app\ntags:\n  - a\ndebug: true\n",
+	} {
+		path := writeFile(t, name, data)
+		var cfg testConfig
+		sources, err := Load(&cfg, File(path))
+		if err != nil {
+			t.Errorf("Failed loading %s: %s", name, err)
+			continue
+		}
+
+		expected := testConfig{
+			DB:    dbConfig{Host: goption.Some("db"), Port: goption.Some(5432)},
+			Name:  goption.Some("app"),
+			Debug: goption.Some(true),
+			Tags:  goption.Some([]string{"a"}),
+		}
+		if !reflect.DeepEqual(cfg, expected) {
+			t.Errorf("Unexpected config from %s: %+v", name, cfg)
+		}
+		if origin := sources.Source("db.port"); origin != "file:"+path {
+			t.Errorf("Unexpected origin for %s: %s", name, origin)
+		}
+		if origin := sources.Source("level"); origin != "" {
+			t.Errorf("Expected level to be unset for %s, got %s", name, origin)
+		}
+	}
+}
+
+func TestFileErrors(t *testing.T) {
+	for _, tt := range []struct {
+		path string
+		want string
+	}{
+		{filepath.Join(t.TempDir(), "missing.json"), "config: open "},
+		{writeFile(t, "config.toml", ""), `config: unknown file extension ".toml" for `},
+		{writeFile(t, "config.json", "{"), "config: parsing "},
+		{writeFile(t, "config.yaml", "db: [\n"), "config: parsing "},
+	} {
+		var cfg testConfig
+		if _, err := Load(&cfg, File(tt.path)); err == nil || !strings.HasPrefix(err.Error(), tt.want) {
+			t.Errorf("Expected an error starting with %q for %s, got %v", tt.want, tt.path, err)
+		}
+	}
+
+	// Values of the wrong type are reported with the file they came from.
+	path := writeFile(t, "config.json", `{"db": {"host": "h", "port": "abc"}, "name": "app"}`)
+	var cfg testConfig
+	if _, err := Load(&cfg, File(path)); err == nil || !strings.HasPrefix(err.Error(), "config: db.port from file:"+path+": ") {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestEnv(t *testing.T) {
+	t.Setenv("APP_DB_HOST", "db")
+	t.Setenv("APP_DB_PORT", "5432")
+	t.Setenv("APP_NAME", "")
+	t.Setenv("APP_TAGS", `["a", "b"]`)
+	t.Setenv("DEBUG", "true")
+
+	var cfg testConfig
+	sources, err := Load(&cfg, Env("APP_"))
+	if err != nil {
+		t.Fatalf("Failed loading: %s", err)
+	}
+
+	expected := testConfig{
+		DB:   dbConfig{Host: goption.Some("db"), Port: goption.Some(5432)},
+		Name: goption.Some(""),
+		Tags: goption.Some([]string{"a", "b"}),
+	}
+	if !reflect.DeepEqual(cfg, expected) {
+		t.Errorf("Unexpected config: %+v", cfg)
+	}
+	if origin := sources.Source("db.port"); origin != "env:APP_DB_PORT" {
+		t.Errorf("Unexpected origin: %s", origin)
+	}
+}
+
+func TestFlags(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	var cfg testConfig
+	sources, err := Load(&cfg, Flags(fs, []string{"-db.host", "db", "-debug", "-timeout=2s", "-name", "app", "rest"}))
+	if err != nil {
+		t.Fatalf("Failed loading: %s", err)
+	}
+
+	if cfg.DB.Host != goption.Some("db") || cfg.Debug != goption.Some(true) || cfg.Name != goption.Some("app") || !cfg.Timeout.Ok() {
+		t.Errorf("Unexpected config: %+v", cfg)
+	}
+	if cfg.DB.Port.Ok() {
+		t.Errorf("Expected db.port to be unset, got %v", cfg.DB.Port)
+	}
+	if origin := sources.Source("debug"); origin != "flag:debug" {
+		t.Errorf("Unexpected origin: %s", origin)
+	}
+	if !reflect.DeepEqual(fs.Args(), []string{"rest"}) {
+		t.Errorf("Expected the remaining arguments to be kept, got %v", fs.Args())
+	}
+}
+
+func TestFlagsError(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	var cfg testConfig
+	if _, err := Load(&cfg, Flags(fs, []string{"-unknown"})); err == nil || !strings.HasPrefix(err.Error(), "config: flag provided but not defined") {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestFlagsTwice(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	other := fs.String("name", "", "not a config flag")
+
+	// name is defined by fs, so Load can't find it.
+	var cfg testConfig
+	if _, err := Load(&cfg, Flags(fs, []string{"-db.host=first"})); err == nil || err.Error() != "config: missing required name" {
+		t.Fatalf("Expected only name to be missing, got %v", err)
+	}
+
+	cfg = testConfig{}
+	sources, err := Load(&cfg, Flags(fs, []string{"-db.host=second", "-name=other"}))
+	if err == nil || err.Error() != "config: missing required name" {
+		t.Fatalf("Expected only name to be missing, got %v", err)
+	}
+	if cfg.DB.Host != goption.Some("second") || sources.Source("db.host") != "flag:db.host" {
+		t.Errorf("Expected the flag to be loaded again, got %v", cfg.DB.Host)
+	}
+	if *other != "other" {
+		t.Errorf("Expected the existing flag to be left alone, got %q", *other)
+	}
+}
+
+func TestPriority(t *testing.T) {
+	path := writeFile(t, "config.json", `{"db": {"host": "file", "port": 1}, "name": "file"}`)
+	t.Setenv("APP_DB_PORT", "2")
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+
+	var cfg testConfig
+	sources, err := Load(&cfg, File(path), Env("APP_"), Flags(fs, []string{"-name=flag"}))
+	if err != nil {
+		t.Fatalf("Failed loading: %s", err)
+	}
+
+	for key, origin := range map[string]string{
+		"db.host": "file:" + path,
+		"db.port": "env:APP_DB_PORT",
+		"name":    "flag:name",
+	} {
+		if got := sources.Source(key); got != origin {
+			t.Errorf("Expected %s to come from %s, got %s", key, origin, got)
+		}
+	}
+	if cfg.DB.Host != goption.Some("file") || cfg.DB.Port != goption.Some(2) || cfg.Name != goption.Some("flag") {
+		t.Errorf("Unexpected config: %+v", cfg)
+	}
+}
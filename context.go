@@ -0,0 +1,54 @@
+package goption
+
+import (
+	"context"
+	"reflect"
+	"time"
+)
+
+// Key is a context key for values of type T. Keys are compared by address,
+// so each declared Key is distinct:
+//
+//	var userKey = goption.NewKey[User]("user")
+//
+//	ctx = userKey.WithValue(ctx, user)
+//	user := userKey.Value(ctx) // Option[User]
+//
+// The zero value is a usable key without a name.
+type Key[T any] struct {
+	// name is only used for debugging, but also keeps Key from being zero
+	// sized, since pointers to zero sized values may not be distinct.
+	name string
+}
+
+// NewKey returns a new key with the given name, which is used by String.
+func NewKey[T any](name string) *Key[T] {
+	return &Key[T]{name: name}
+}
+
+// WithValue returns a copy of ctx in which k is associated with v.
+func (k *Key[T]) WithValue(ctx context.Context, v T) context.Context {
+	return context.WithValue(ctx, k, v)
+}
+
+// Value returns the value associated with k in ctx, or none if there isn't one.
+func (k *Key[T]) Value(ctx context.Context) Option[T] {
+	return Cast[T](ctx.Value(k))
+}
+
+// String returns the name of the key, for debugging.
+func (k *Key[T]) String() string {
+	return "goption.Key[" + reflect.TypeFor[T]().String() + "](" + k.name + ")"
+}
+
+// Deadline returns the deadline of ctx, or none if it doesn't have one.
+func Deadline(ctx context.Context) Option[time.Time] {
+	return fromOk(ctx.Deadline())
+}
+
+// Cause returns the reason ctx was canceled, as given by context.Cause, or
+// none if ctx hasn't been canceled yet.
+func Cause(ctx context.Context) Option[error] {
+	cause := context.Cause(ctx)
+	return fromOk(cause, cause != nil)
+}
@@ -0,0 +1,83 @@
+package goption
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestKey(t *testing.T) {
+	userKey := NewKey[string]("user")
+	otherKey := NewKey[string]("user")
+	var countKey Key[int]
+
+	ctx := userKey.WithValue(context.Background(), "jordan")
+	ctx = countKey.WithValue(ctx, 0)
+
+	if o := userKey.Value(ctx); o != Some("jordan") {
+		t.Errorf("Expected Some(jordan), got %v", o)
+	}
+	if o := countKey.Value(ctx); o != Some(0) {
+		t.Errorf("Expected Some(0), got %v", o)
+	}
+	if o := otherKey.Value(ctx); o.Ok() {
+		t.Errorf("Expected keys with the same name to be distinct, got %v", o)
+	}
+	if o := userKey.Value(context.Background()); o.Ok() {
+		t.Errorf("Expected none, got %v", o)
+	}
+}
+
+func TestKeyNilValue(t *testing.T) {
+	errKey := NewKey[error]("err")
+	ctx := errKey.WithValue(context.Background(), nil)
+	if o := errKey.Value(ctx); o.Ok() {
+		t.Errorf("Expected a nil interface to be none, got %v", o)
+	}
+}
+
+func TestKeyString(t *testing.T) {
+	timeoutKey := NewKey[time.Duration]("timeout")
+	if s := timeoutKey.String(); s != "goption.Key[time.Duration](timeout)" {
+		t.Errorf("Unexpected name: %s", s)
+	}
+
+	ctx := timeoutKey.WithValue(context.Background(), time.Second)
+	if s := ctx.(interface{ String() string }).String(); !strings.Contains(s, "goption.Key[time.Duration](timeout)") {
+		t.Errorf("Expected the context to name the key: %s", s)
+	}
+}
+
+func TestDeadline(t *testing.T) {
+	if o := Deadline(context.Background()); o.Ok() {
+		t.Errorf("Expected no deadline, got %v", o)
+	}
+
+	deadline := time.Now().Add(time.Hour)
+	ctx, cancel := context.WithDeadline(context.Background(), deadline)
+	defer cancel()
+	if o := Deadline(ctx); !o.Ok() || !o.Unwrap().Equal(deadline) {
+		t.Errorf("Expected %v, got %v", deadline, o)
+	}
+}
+
+func TestCause(t *testing.T) {
+	ctx, cancel := context.WithCancelCause(context.Background())
+	if o := Cause(ctx); o.Ok() {
+		t.Errorf("Expected no cause, got %v", o)
+	}
+
+	failed := errors.New("failed")
+	cancel(failed)
+	if o := Cause(ctx); o != Some(failed) {
+		t.Errorf("Expected the cause, got %v", o)
+	}
+
+	ctx, cancelPlain := context.WithCancel(context.Background())
+	cancelPlain()
+	if o := Cause(ctx); o != Some(context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", o)
+	}
+}
@@ -0,0 +1,52 @@
+package goption
+
+import (
+	"errors"
+)
+
+// ErrorAs returns the first error in err's tree which is an E, as found by
+// errors.As, or none if there isn't one.
+func ErrorAs[E error](err error) Option[E] {
+	var e E
+	return fromOk(e, errors.As(err, &e))
+}
+
+// ErrorCause returns the error wrapped by err, as given by errors.Unwrap, or
+// none if err doesn't wrap a single error.
+func ErrorCause(err error) Option[error] {
+	cause := errors.Unwrap(err)
+	return fromOk(cause, cause != nil)
+}
+
+// JoinedErrors returns the errors joined in err, such as by errors.Join.
+// An error which isn't joined is returned alone, and a nil err returns nil.
+func JoinedErrors(err error) []error {
+	if joined, isJoined := err.(interface{ Unwrap() []error }); isJoined {
+		return joined.Unwrap()
+	}
+	if err == nil {
+		return nil
+	}
+
+	return []error{err}
+}
+
+// OkOrErr returns the underlying value and a nil error if it's present,
+// otherwise it returns the zero value of T and err.
+func (o Option[T]) OkOrErr(err error) (T, error) {
+	if !o.ok {
+		return o.t, err
+	}
+
+	return o.t, nil
+}
+
+// OkOrElse is like OkOrErr but the error is only built by calling f if o is
+// empty.
+func (o Option[T]) OkOrElse(f func() error) (T, error) {
+	if !o.ok {
+		return o.t, f()
+	}
+
+	return o.t, nil
+}
@@ -0,0 +1,93 @@
+package goption
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"testing"
+)
+
+type errCode int
+
+func (e errCode) Error() string {
+	return fmt.Sprintf("code %d", int(e))
+}
+
+type timeoutError interface {
+	error
+	Timeout() bool
+}
+
+func TestErrorAs(t *testing.T) {
+	err := fmt.Errorf("wrapped: %w", &fs.PathError{Op: "open", Path: "x", Err: fs.ErrNotExist})
+	if o := ErrorAs[*fs.PathError](err); !o.Ok() || o.Unwrap().Path != "x" {
+		t.Errorf("Expected the path error, got %v", o)
+	}
+	if o := ErrorAs[errCode](err); o.Ok() {
+		t.Errorf("Expected none, got %v", o)
+	}
+	if o := ErrorAs[errCode](errors.Join(errors.New("a"), errCode(3))); o != Some(errCode(3)) {
+		t.Errorf("Expected code 3, got %v", o)
+	}
+	if o := ErrorAs[timeoutError](os.ErrDeadlineExceeded); !o.Ok() || !o.Unwrap().Timeout() {
+		t.Errorf("Expected an interface to be found, got %v", o)
+	}
+	if o := ErrorAs[errCode](nil); o.Ok() {
+		t.Errorf("Expected none for nil, got %v", o)
+	}
+}
+
+func TestErrorCause(t *testing.T) {
+	cause := errors.New("cause")
+	if o := ErrorCause(fmt.Errorf("wrapped: %w", cause)); o != Some(cause) {
+		t.Errorf("Expected the cause, got %v", o)
+	}
+	if o := ErrorCause(cause); o.Ok() {
+		t.Errorf("Expected none, got %v", o)
+	}
+	if o := ErrorCause(errors.Join(cause, cause)); o.Ok() {
+		t.Errorf("Expected none for a joined error, got %v", o)
+	}
+}
+
+func TestJoinedErrors(t *testing.T) {
+	a, b := errors.New("a"), errors.New("b")
+	if errs := JoinedErrors(errors.Join(a, nil, b)); len(errs) != 2 || errs[0] != a || errs[1] != b {
+		t.Errorf("Expected a and b, got %v", errs)
+	}
+	if errs := JoinedErrors(fmt.Errorf("%w and %w", a, b)); len(errs) != 2 {
+		t.Errorf("Expected both errors, got %v", errs)
+	}
+	if errs := JoinedErrors(a); len(errs) != 1 || errs[0] != a {
+		t.Errorf("Expected a alone, got %v", errs)
+	}
+	if errs := JoinedErrors(nil); errs != nil {
+		t.Errorf("Expected nil, got %v", errs)
+	}
+}
+
+func TestOkOrErr(t *testing.T) {
+	missing := errors.New("missing")
+	if v, err := Some(3).OkOrErr(missing); v != 3 || err != nil {
+		t.Errorf("Expected 3, got %v, %v", v, err)
+	}
+	if v, err := None[int]().OkOrErr(missing); v != 0 || err != missing {
+		t.Errorf("Expected the error, got %v, %v", v, err)
+	}
+}
+
+func TestOkOrElse(t *testing.T) {
+	calls := 0
+	f := func() error {
+		calls++
+		return errors.New("missing")
+	}
+
+	if v, err := Some("a").OkOrElse(f); v != "a" || err != nil || calls != 0 {
+		t.Errorf("Expected a without calling f, got %v, %v", v, err)
+	}
+	if v, err := None[string]().OkOrElse(f); v != "" || err == nil || calls != 1 {
+		t.Errorf("Expected the error, got %v, %v", v, err)
+	}
+}
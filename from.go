@@ -0,0 +1,91 @@
+package goption
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+// fromOk returns Some(t) if ok, otherwise it returns an empty optional value.
+func fromOk[T any](t T, ok bool) Option[T] {
+	if !ok {
+		return None[T]()
+	}
+	return Some(t)
+}
+
+// fromErr returns Some(t) if err is nil, otherwise it returns an empty optional value.
+func fromErr[T any](t T, err error) Option[T] {
+	return fromOk(t, err == nil)
+}
+
+// Index returns s[i] if i is in range, otherwise it returns an empty optional value.
+func Index[T any](s []T, i int) Option[T] {
+	if i < 0 || i >= len(s) {
+		return None[T]()
+	}
+	return FromRef(&s[i])
+}
+
+// Lookup returns m[k] if k is in m, otherwise it returns an empty optional value.
+func Lookup[K comparable, V any](m map[K]V, k K) Option[V] {
+	v, ok := m[k]
+	return fromOk(v, ok)
+}
+
+// Recv blocks until a value is received from ch.
+// If ch is closed an empty optional value is returned.
+func Recv[T any](ch <-chan T) Option[T] {
+	v, ok := <-ch
+	return fromOk(v, ok)
+}
+
+// TryRecv receives a value from ch without blocking.
+// If no value is ready or ch is closed an empty optional value is returned.
+func TryRecv[T any](ch <-chan T) Option[T] {
+	select {
+	case v, ok := <-ch:
+		return fromOk(v, ok)
+	default:
+		return None[T]()
+	}
+}
+
+// Cast returns v as a T if it holds one, otherwise it returns an empty optional value.
+func Cast[T any](v any) Option[T] {
+	t, ok := v.(T)
+	return fromOk(t, ok)
+}
+
+// ParseInt wraps strconv.ParseInt, returning an empty optional value if s is invalid.
+func ParseInt(s string, base int, bitSize int) Option[int64] {
+	return fromErr(strconv.ParseInt(s, base, bitSize))
+}
+
+// ParseFloat wraps strconv.ParseFloat, returning an empty optional value if s is invalid.
+func ParseFloat(s string, bitSize int) Option[float64] {
+	return fromErr(strconv.ParseFloat(s, bitSize))
+}
+
+// ParseBool wraps strconv.ParseBool, returning an empty optional value if s is invalid.
+func ParseBool(s string) Option[bool] {
+	return fromErr(strconv.ParseBool(s))
+}
+
+// ParseDuration wraps time.ParseDuration, returning an empty optional value if s is invalid.
+func ParseDuration(s string) Option[time.Duration] {
+	return fromErr(time.ParseDuration(s))
+}
+
+// Env returns the environment variable named name if it is set, otherwise it
+// returns an empty optional value. A variable set to the empty string is present.
+func Env(name string) Option[string] {
+	return fromOk(os.LookupEnv(name))
+}
+
+// FromZero returns an empty optional value if v is the zero value for T.
+// Otherwise, it returns Some(v).
+func FromZero[T comparable](v T) Option[T] {
+	var zero T
+	return fromOk(v, v != zero)
+}
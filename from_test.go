@@ -0,0 +1,124 @@
+package goption
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIndex(t *testing.T) {
+	s := []int{1, 2, 3}
+	if v := Index(s, 1); v.UnwrapOr(-1) != 2 {
+		t.Errorf("Expected 2, got %v", v)
+	}
+	if v := Index(s, 3); v.Ok() {
+		t.Errorf("Expected none past the end, got %v", v)
+	}
+	if v := Index(s, -1); v.Ok() {
+		t.Errorf("Expected none for negative index, got %v", v)
+	}
+	if v := Index([]int(nil), 0); v.Ok() {
+		t.Errorf("Expected none for nil slice, got %v", v)
+	}
+}
+
+func TestLookup(t *testing.T) {
+	m := map[string]int{"zero": 0}
+	if v := Lookup(m, "zero"); !v.Ok() || v.Unwrap() != 0 {
+		t.Errorf("Expected some 0, got %v", v)
+	}
+	if v := Lookup(m, "one"); v.Ok() {
+		t.Errorf("Expected none for missing key, got %v", v)
+	}
+}
+
+func TestRecv(t *testing.T) {
+	ch := make(chan int, 1)
+	ch <- 3
+	if v := Recv(ch); v.UnwrapOr(-1) != 3 {
+		t.Errorf("Expected 3, got %v", v)
+	}
+
+	close(ch)
+	if v := Recv(ch); v.Ok() {
+		t.Errorf("Expected none for closed channel, got %v", v)
+	}
+}
+
+func TestTryRecv(t *testing.T) {
+	ch := make(chan int, 1)
+	if v := TryRecv(ch); v.Ok() {
+		t.Errorf("Expected none for empty channel, got %v", v)
+	}
+
+	ch <- 3
+	if v := TryRecv(ch); v.UnwrapOr(-1) != 3 {
+		t.Errorf("Expected 3, got %v", v)
+	}
+
+	close(ch)
+	if v := TryRecv(ch); v.Ok() {
+		t.Errorf("Expected none for closed channel, got %v", v)
+	}
+}
+
+func TestCast(t *testing.T) {
+	var v any = 3
+	if i := Cast[int](v); i.UnwrapOr(-1) != 3 {
+		t.Errorf("Expected 3, got %v", i)
+	}
+	if s := Cast[string](v); s.Ok() {
+		t.Errorf("Expected none, got %v", s)
+	}
+	if s := Cast[IsStringer](nil); s.Ok() {
+		t.Errorf("Expected none for nil, got %v", s)
+	}
+}
+
+func TestParse(t *testing.T) {
+	if v := ParseInt("-12", 10, 64); v.UnwrapOr(0) != -12 {
+		t.Errorf("Expected -12, got %v", v)
+	}
+	if v := ParseInt("300", 10, 8); v.Ok() {
+		t.Errorf("Expected none for out of range int, got %v", v)
+	}
+	if v := ParseFloat("1.5", 64); v.UnwrapOr(0) != 1.5 {
+		t.Errorf("Expected 1.5, got %v", v)
+	}
+	if v := ParseFloat("one", 64); v.Ok() {
+		t.Errorf("Expected none, got %v", v)
+	}
+	if v := ParseBool("true"); !v.UnwrapOr(false) {
+		t.Errorf("Expected true, got %v", v)
+	}
+	if v := ParseBool("yes"); v.Ok() {
+		t.Errorf("Expected none, got %v", v)
+	}
+	if v := ParseDuration("1m"); v.UnwrapOr(0) != time.Minute {
+		t.Errorf("Expected 1m, got %v", v)
+	}
+	if v := ParseDuration("1 minute"); v.Ok() {
+		t.Errorf("Expected none, got %v", v)
+	}
+}
+
+func TestEnv(t *testing.T) {
+	t.Setenv("GOPTION_TEST_SET", "")
+	if v := Env("GOPTION_TEST_SET"); !v.Ok() || v.Unwrap() != "" {
+		t.Errorf("Expected some empty string, got %v", v)
+	}
+	if v := Env("GOPTION_TEST_NOT_SET"); v.Ok() {
+		t.Errorf("Expected none, got %v", v)
+	}
+}
+
+func TestFromZero(t *testing.T) {
+	if v := FromZero(0); v.Ok() {
+		t.Errorf("Expected none for zero, got %v", v)
+	}
+	if v := FromZero(""); v.Ok() {
+		t.Errorf("Expected none for empty string, got %v", v)
+	}
+	if v := FromZero("hey!"); v.UnwrapOr("") != "hey!" {
+		t.Errorf("Expected hey!, got %v", v)
+	}
+}
@@ -0,0 +1,156 @@
+package goption
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// fuzzJSON checks that Option[T] decodes data like encoding/json decodes T,
+// and that whatever it decodes survives a round trip.
+func fuzzJSON[T any](t *testing.T, data []byte) {
+	var opt Option[T]
+	err := opt.UnmarshalJSON(data)
+
+	if string(data) != "null" {
+		var expected T
+		expectedErr := json.Unmarshal(data, &expected)
+		if (err == nil) != (expectedErr == nil) {
+			t.Fatalf("Option[%T] error for %q was %v, expected %v", expected, data, err, expectedErr)
+		}
+		if err == nil && !reflect.DeepEqual(opt.t, expected) {
+			t.Fatalf("Option[%T] decoded %q as %#v, expected %#v", expected, data, opt.t, expected)
+		}
+	}
+	if err != nil {
+		return
+	}
+
+	encoded, err := opt.MarshalJSON()
+	if err != nil {
+		t.Fatalf("Failed marshalling %#v: %s", opt, err)
+	}
+
+	var decoded Option[T]
+	if err := decoded.UnmarshalJSON(encoded); err != nil {
+		t.Fatalf("Failed unmarshalling %q: %s", encoded, err)
+	}
+
+	reencoded, err := decoded.MarshalJSON()
+	if err != nil {
+		t.Fatalf("Failed marshalling %#v: %s", decoded, err)
+	}
+	if !bytes.Equal(encoded, reencoded) {
+		t.Fatalf("%q didn't round trip, got %q", encoded, reencoded)
+	}
+}
+
+func FuzzJSON(f *testing.F) {
+	f.Fuzz(func(t *testing.T, data []byte) {
+		fuzzJSON[bool](t, data)
+		fuzzJSON[int](t, data)
+		fuzzJSON[int8](t, data)
+		fuzzJSON[uint64](t, data)
+		fuzzJSON[float32](t, data)
+		fuzzJSON[float64](t, data)
+		fuzzJSON[string](t, data)
+		fuzzJSON[time.Time](t, data)
+		fuzzJSON[Bar](t, data)
+		fuzzJSON[[]int](t, data)
+		fuzzJSON[Option[int]](t, data)
+	})
+}
+
+// fuzzText checks that whatever Option[T] decodes from data survives a
+// round trip through MarshalText and UnmarshalText.
+func fuzzText[T any](t *testing.T, data []byte) {
+	var opt Option[T]
+	if err := opt.UnmarshalText(data); err != nil {
+		return
+	}
+
+	encoded, err := opt.MarshalText()
+	if err != nil {
+		t.Fatalf("Failed marshalling %#v: %s", opt, err)
+	}
+
+	var decoded Option[T]
+	if err := decoded.UnmarshalText(encoded); err != nil {
+		t.Fatalf("Failed unmarshalling %q: %s", encoded, err)
+	}
+
+	reencoded, err := decoded.MarshalText()
+	if err != nil {
+		t.Fatalf("Failed marshalling %#v: %s", decoded, err)
+	}
+	if !bytes.Equal(encoded, reencoded) {
+		t.Fatalf("%q didn't round trip, got %q", encoded, reencoded)
+	}
+}
+
+func FuzzText(f *testing.F) {
+	f.Fuzz(func(t *testing.T, data []byte) {
+		fuzzText[int](t, data)
+		fuzzText[float64](t, data)
+		fuzzText[string](t, data)
+		fuzzText[time.Time](t, data)
+		fuzzText[Foo](t, data)
+		fuzzText[Option[time.Time]](t, data)
+	})
+}
+
+// fuzzSQL checks that whatever Option[T] scans from src survives a round
+// trip through Value and Scan.
+func fuzzSQL[T comparable](t *testing.T, src any) {
+	var opt Option[T]
+	if err := opt.Scan(src); err != nil {
+		return
+	}
+
+	value, err := opt.Value()
+	if err != nil {
+		t.Fatalf("Failed getting value of %#v: %s", opt, err)
+	}
+
+	var scanned Option[T]
+	if err := scanned.Scan(value); err != nil {
+		t.Fatalf("Failed scanning %#v: %s", value, err)
+	}
+	if scanned != opt {
+		t.Fatalf("%#v didn't round trip, got %#v", opt, scanned)
+	}
+}
+
+func FuzzSQL(f *testing.F) {
+	f.Fuzz(func(t *testing.T, s string, i int64, fl float64, b bool) {
+		for _, src := range []any{s, []byte(s), i, fl, b, time.Unix(i, 0)} {
+			fuzzSQL[bool](t, src)
+			fuzzSQL[int](t, src)
+			fuzzSQL[uint16](t, src)
+			fuzzSQL[float32](t, src)
+			fuzzSQL[float64](t, src)
+			fuzzSQL[string](t, src)
+			fuzzSQL[time.Time](t, src)
+		}
+	})
+}
+
+func FuzzString(f *testing.F) {
+	f.Fuzz(func(t *testing.T, s string, i int64) {
+		if str := Some(s).String(); str != s {
+			t.Fatalf("Some(%q).String() was %q", s, str)
+		}
+		if str := Some(i).String(); str != fmt.Sprint(i) {
+			t.Fatalf("Some(%d).String() was %q", i, str)
+		}
+		if str := Some(s).GoString(); str != fmt.Sprintf("%#v", s) {
+			t.Fatalf("Some(%q).GoString() was %q", s, str)
+		}
+		if str := Some([]byte(s)).GoString(); str != fmt.Sprintf("%#v", []byte(s)) {
+			t.Fatalf("Some(%q).GoString() was %q", s, str)
+		}
+	})
+}
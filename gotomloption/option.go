@@ -0,0 +1,143 @@
+// Package gotomloption adapts goption.Option for use with
+// github.com/pelletier/go-toml/v2.
+//
+// go-toml only consults the Option hooks when they're enabled on the encoder
+// and decoder, which Marshal, Unmarshal, NewEncoder and NewDecoder take care
+// of. TOML has no null, so empty options are left out of the encoded document
+// and absent keys decode as empty options.
+package gotomloption
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/jordan-bonecutter/goption"
+	"github.com/pelletier/go-toml/v2"
+	"github.com/pelletier/go-toml/v2/unstable"
+)
+
+// Option wraps goption.Option so that it implements unstable.Marshaler and
+// unstable.Unmarshaler. Every method of goption.Option is available on it.
+type Option[T any] struct {
+	goption.Option[T]
+}
+
+// Wrap returns o as a go-toml compatible Option.
+func Wrap[T any](o goption.Option[T]) Option[T] {
+	return Option[T]{o}
+}
+
+// Some returns an Option whose underlying value is present.
+func Some[T any](t T) Option[T] {
+	return Wrap(goption.Some(t))
+}
+
+// None returns an empty optional value.
+func None[T any]() Option[T] {
+	return Wrap(goption.None[T]())
+}
+
+// NewEncoder returns a toml.Encoder which writes to w and understands Options.
+func NewEncoder(w io.Writer) *toml.Encoder {
+	return toml.NewEncoder(w).EnableMarshalerInterface()
+}
+
+// NewDecoder returns a toml.Decoder which reads from r and understands Options.
+func NewDecoder(r io.Reader) *toml.Decoder {
+	return toml.NewDecoder(r).EnableUnmarshalerInterface()
+}
+
+// Marshal is like toml.Marshal but understands Options.
+func Marshal(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := NewEncoder(&buf).Encode(v); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Unmarshal is like toml.Unmarshal but understands Options.
+func Unmarshal(data []byte, v any) error {
+	return NewDecoder(bytes.NewReader(data)).Decode(v)
+}
+
+// wrapped holds a single value so that it can be encoded or decoded as a
+// key value pair.
+type wrapped[T any] struct {
+	V T `toml:"v"`
+}
+
+// valuePrefix is the key written before every encoded wrapped value.
+var valuePrefix = []byte("v = ")
+
+// MarshalTOML implements unstable.Marshaler.
+// Empty options marshal to nothing, which go-toml omits from the document.
+// The underlying value is written inline, unless it's a table holding empty
+// options, which can only be left out of a table body.
+func (o Option[T]) MarshalTOML() ([]byte, error) {
+	t, ok := o.Get()
+	if !ok {
+		return nil, nil
+	}
+
+	var buf bytes.Buffer
+	enc := NewEncoder(&buf).SetTablesInline(true)
+	if err := enc.Encode(wrapped[T]{V: t}); err != nil {
+		if body := marshalTableBody(t); len(body) > 0 {
+			return body, nil
+		}
+		return nil, err
+	}
+
+	encoded := bytes.TrimSpace(buf.Bytes())
+	if !bytes.HasPrefix(encoded, valuePrefix) {
+		return nil, fmt.Errorf("gotomloption: unexpected encoding for %T: %q", t, encoded)
+	}
+
+	return encoded[len(valuePrefix):], nil
+}
+
+// marshalTableBody returns the key value pairs of t, or nil if t can't be
+// encoded as a table.
+func marshalTableBody(t any) []byte {
+	var buf bytes.Buffer
+	if err := NewEncoder(&buf).SetTablesInline(true).Encode(t); err != nil {
+		return nil
+	}
+
+	return bytes.TrimSpace(buf.Bytes())
+}
+
+// UnmarshalTOML implements unstable.Unmarshaler.
+// go-toml hands over a single value for key value pairs and the body of the
+// table for tables.
+func (o *Option[T]) UnmarshalTOML(data []byte) error {
+	value := append(append([]byte{}, valuePrefix...), data...)
+	if isDocument(value) {
+		var decoded wrapped[T]
+		if err := Unmarshal(value, &decoded); err != nil {
+			return err
+		}
+		*o = Some(decoded.V)
+		return nil
+	}
+
+	var t T
+	if err := Unmarshal(data, &t); err != nil {
+		return err
+	}
+	*o = Some(t)
+	return nil
+}
+
+// isDocument returns true if data is valid TOML.
+func isDocument(data []byte) bool {
+	var p unstable.Parser
+	p.Reset(data)
+	for p.NextExpression() {
+	}
+
+	return p.Error() == nil
+}
@@ -0,0 +1,186 @@
+package gotomloption
+
+import (
+	"bytes"
+	"math/rand"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jordan-bonecutter/goption/optiontest"
+)
+
+type limits struct {
+	Connections int         `toml:"connections"`
+	Idle        Option[int] `toml:"idle"`
+}
+
+type database struct {
+	Host    string              `toml:"host"`
+	User    Option[string]      `toml:"user"`
+	Limits  Option[limits]      `toml:"limits"`
+	Started Option[time.Time]   `toml:"started"`
+	Ratio   Option[float64]     `toml:"ratio"`
+	Nested  Option[Option[int]] `toml:"nested"`
+}
+
+type server struct {
+	Name    string           `toml:"name"`
+	Port    Option[int]      `toml:"port"`
+	Tags    Option[[]string] `toml:"tags"`
+	Enabled Option[bool]     `toml:"enabled"`
+}
+
+type config struct {
+	Title    Option[string] `toml:"title"`
+	Owner    Option[string] `toml:"owner"`
+	Database database       `toml:"database"`
+	Servers  []server       `toml:"servers"`
+}
+
+var golden = config{
+	Title: Some("example"),
+	Database: database{
+		Host: "localhost",
+		User: Some("admin"),
+		Limits: Some(limits{
+			Connections: 10,
+			Idle:        Some(2),
+		}),
+		Started: Some(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)),
+		Nested:  Some(Some(0)),
+	},
+	Servers: []server{
+		{
+			Name:    "alpha",
+			Port:    Some(8080),
+			Tags:    Some([]string{"a", "b"}),
+			Enabled: Some(false),
+		},
+		{
+			Name: "beta",
+		},
+	},
+}
+
+func TestMarshalGolden(t *testing.T) {
+	expected, err := os.ReadFile("testdata/config.toml")
+	if err != nil {
+		t.Fatalf("Failed reading golden file: %s", err)
+	}
+
+	encoded, err := Marshal(golden)
+	if err != nil {
+		t.Fatalf("Failed marshalling toml: %s", err)
+	}
+
+	if !bytes.Equal(encoded, expected) {
+		t.Errorf("Unexpected encoded data:\n%s\nexpected:\n%s", encoded, expected)
+	}
+}
+
+func TestUnmarshalGolden(t *testing.T) {
+	data, err := os.ReadFile("testdata/config.toml")
+	if err != nil {
+		t.Fatalf("Failed reading golden file: %s", err)
+	}
+
+	var decoded config
+	if err := Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Failed unmarshalling toml: %s", err)
+	}
+
+	if !reflect.DeepEqual(decoded, golden) {
+		t.Errorf("Unexpected decoded data:\n%#v\nexpected:\n%#v", decoded, golden)
+	}
+}
+
+func TestUnmarshalTable(t *testing.T) {
+	var decoded database
+	err := Unmarshal([]byte("host = \"localhost\"\n\n[limits]\nconnections = 10\nidle = 2\n"), &decoded)
+	if err != nil {
+		t.Fatalf("Failed unmarshalling toml: %s", err)
+	}
+
+	if decoded.Limits.UnwrapOrDefault() != (limits{Connections: 10, Idle: Some(2)}) {
+		t.Errorf("Unexpected limits: %v", decoded.Limits)
+	}
+}
+
+func TestMarshalNoneInArray(t *testing.T) {
+	_, err := Marshal(struct {
+		Ports []Option[int] `toml:"ports"`
+	}{
+		Ports: []Option[int]{Some(1), None[int]()},
+	})
+	if err == nil {
+		t.Errorf("Expected an error marshalling none inside an array")
+	}
+}
+
+func TestUnmarshalTypeError(t *testing.T) {
+	var decoded struct {
+		Port Option[int] `toml:"port"`
+	}
+	if err := Unmarshal([]byte(`port = "abc"`), &decoded); err == nil {
+		t.Errorf("Expected an error decoding a string into an int")
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	rand := rand.New(rand.NewSource(1))
+	values := make([]database, 200)
+	for i := range values {
+		v := database{
+			Host:  "localhost",
+			User:  Wrap(optiontest.Generate[string](rand, 10)),
+			Ratio: Wrap(optiontest.Generate[float64](rand, 10)),
+		}
+		if rand.Intn(2) == 0 {
+			v.Limits = Some(limits{
+				Connections: rand.Int(),
+				Idle:        Wrap(optiontest.Generate[int](rand, 10)),
+			})
+		}
+		if rand.Intn(2) == 0 {
+			v.Started = Some(time.Unix(rand.Int63n(1<<35), rand.Int63n(int64(time.Second))).UTC())
+		}
+		// Some(None) is encoded as None, so only nest present values.
+		if nested := optiontest.Generate[int](rand, 10); nested.Ok() {
+			v.Nested = Some(Wrap(nested))
+		}
+		values[i] = v
+	}
+
+	optiontest.RoundTrip(t, optiontest.Codec{
+		Name:      "go-toml",
+		Marshal:   Marshal,
+		Unmarshal: Unmarshal,
+	}, values)
+}
+
+func TestMarshalTableWithNone(t *testing.T) {
+	v := database{
+		Host:   "localhost",
+		Limits: Some(limits{Connections: 10}),
+	}
+
+	encoded, err := Marshal(v)
+	if err != nil {
+		t.Fatalf("Failed marshalling toml: %s", err)
+	}
+
+	if string(encoded) != "host = 'localhost'\n\n[limits]\nconnections = 10\n" {
+		t.Errorf("Unexpected encoded data:\n%s", encoded)
+	}
+
+	var decoded database
+	if err := Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("Failed unmarshalling toml: %s", err)
+	}
+
+	if !reflect.DeepEqual(decoded, v) {
+		t.Errorf("Unexpected decoded data: %#v", decoded)
+	}
+}
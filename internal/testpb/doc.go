@@ -0,0 +1,5 @@
+// Package testpb holds protobuf messages used to test protooption and the code
+// generated by protoc-gen-go-option.
+package testpb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-option_out=. --go-option_opt=paths=source_relative example.proto
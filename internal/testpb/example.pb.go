@@ -0,0 +1,383 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: example.proto
+
+package testpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	wrapperspb "google.golang.org/protobuf/types/known/wrapperspb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Color int32
+
+const (
+	Color_COLOR_UNSPECIFIED Color = 0
+	Color_COLOR_RED         Color = 1
+)
+
+// Enum value maps for Color.
+var (
+	Color_name = map[int32]string{
+		0: "COLOR_UNSPECIFIED",
+		1: "COLOR_RED",
+	}
+	Color_value = map[string]int32{
+		"COLOR_UNSPECIFIED": 0,
+		"COLOR_RED":         1,
+	}
+)
+
+func (x Color) Enum() *Color {
+	p := new(Color)
+	*p = x
+	return p
+}
+
+func (x Color) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Color) Descriptor() protoreflect.EnumDescriptor {
+	return file_example_proto_enumTypes[0].Descriptor()
+}
+
+func (Color) Type() protoreflect.EnumType {
+	return &file_example_proto_enumTypes[0]
+}
+
+func (x Color) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Color.Descriptor instead.
+func (Color) EnumDescriptor() ([]byte, []int) {
+	return file_example_proto_rawDescGZIP(), []int{0}
+}
+
+type Example struct {
+	state          protoimpl.MessageState  `protogen:"open.v1"`
+	Count          *int32                  `protobuf:"varint,1,opt,name=count,proto3,oneof" json:"count,omitempty"`
+	Name           *string                 `protobuf:"bytes,2,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	Payload        []byte                  `protobuf:"bytes,3,opt,name=payload,proto3,oneof" json:"payload,omitempty"`
+	Color          *Color                  `protobuf:"varint,4,opt,name=color,proto3,enum=goption.testpb.Color,oneof" json:"color,omitempty"`
+	Ratio          *float64                `protobuf:"fixed64,5,opt,name=ratio,proto3,oneof" json:"ratio,omitempty"`
+	WrappedCount   *wrapperspb.Int32Value  `protobuf:"bytes,6,opt,name=wrapped_count,json=wrappedCount,proto3" json:"wrapped_count,omitempty"`
+	WrappedName    *wrapperspb.StringValue `protobuf:"bytes,7,opt,name=wrapped_name,json=wrappedName,proto3" json:"wrapped_name,omitempty"`
+	WrappedPayload *wrapperspb.BytesValue  `protobuf:"bytes,8,opt,name=wrapped_payload,json=wrappedPayload,proto3" json:"wrapped_payload,omitempty"`
+	Plain          int32                   `protobuf:"varint,9,opt,name=plain,proto3" json:"plain,omitempty"`
+	List           []int32                 `protobuf:"varint,10,rep,packed,name=list,proto3" json:"list,omitempty"`
+	Nested         *Example_Nested         `protobuf:"bytes,11,opt,name=nested,proto3" json:"nested,omitempty"`
+	// Types that are valid to be assigned to Choice:
+	//
+	//	*Example_First
+	//	*Example_Second
+	Choice        isExample_Choice `protobuf_oneof:"choice"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Example) Reset() {
+	*x = Example{}
+	mi := &file_example_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Example) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Example) ProtoMessage() {}
+
+func (x *Example) ProtoReflect() protoreflect.Message {
+	mi := &file_example_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Example.ProtoReflect.Descriptor instead.
+func (*Example) Descriptor() ([]byte, []int) {
+	return file_example_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Example) GetCount() int32 {
+	if x != nil && x.Count != nil {
+		return *x.Count
+	}
+	return 0
+}
+
+func (x *Example) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *Example) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *Example) GetColor() Color {
+	if x != nil && x.Color != nil {
+		return *x.Color
+	}
+	return Color_COLOR_UNSPECIFIED
+}
+
+func (x *Example) GetRatio() float64 {
+	if x != nil && x.Ratio != nil {
+		return *x.Ratio
+	}
+	return 0
+}
+
+func (x *Example) GetWrappedCount() *wrapperspb.Int32Value {
+	if x != nil {
+		return x.WrappedCount
+	}
+	return nil
+}
+
+func (x *Example) GetWrappedName() *wrapperspb.StringValue {
+	if x != nil {
+		return x.WrappedName
+	}
+	return nil
+}
+
+func (x *Example) GetWrappedPayload() *wrapperspb.BytesValue {
+	if x != nil {
+		return x.WrappedPayload
+	}
+	return nil
+}
+
+func (x *Example) GetPlain() int32 {
+	if x != nil {
+		return x.Plain
+	}
+	return 0
+}
+
+func (x *Example) GetList() []int32 {
+	if x != nil {
+		return x.List
+	}
+	return nil
+}
+
+func (x *Example) GetNested() *Example_Nested {
+	if x != nil {
+		return x.Nested
+	}
+	return nil
+}
+
+func (x *Example) GetChoice() isExample_Choice {
+	if x != nil {
+		return x.Choice
+	}
+	return nil
+}
+
+func (x *Example) GetFirst() int32 {
+	if x != nil {
+		if x, ok := x.Choice.(*Example_First); ok {
+			return x.First
+		}
+	}
+	return 0
+}
+
+func (x *Example) GetSecond() string {
+	if x != nil {
+		if x, ok := x.Choice.(*Example_Second); ok {
+			return x.Second
+		}
+	}
+	return ""
+}
+
+type isExample_Choice interface {
+	isExample_Choice()
+}
+
+type Example_First struct {
+	First int32 `protobuf:"varint,12,opt,name=first,proto3,oneof"`
+}
+
+type Example_Second struct {
+	Second string `protobuf:"bytes,13,opt,name=second,proto3,oneof"`
+}
+
+func (*Example_First) isExample_Choice() {}
+
+func (*Example_Second) isExample_Choice() {}
+
+type Example_Nested struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Enabled       *bool                  `protobuf:"varint,1,opt,name=enabled,proto3,oneof" json:"enabled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Example_Nested) Reset() {
+	*x = Example_Nested{}
+	mi := &file_example_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Example_Nested) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Example_Nested) ProtoMessage() {}
+
+func (x *Example_Nested) ProtoReflect() protoreflect.Message {
+	mi := &file_example_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Example_Nested.ProtoReflect.Descriptor instead.
+func (*Example_Nested) Descriptor() ([]byte, []int) {
+	return file_example_proto_rawDescGZIP(), []int{0, 0}
+}
+
+func (x *Example_Nested) GetEnabled() bool {
+	if x != nil && x.Enabled != nil {
+		return *x.Enabled
+	}
+	return false
+}
+
+var File_example_proto protoreflect.FileDescriptor
+
+const file_example_proto_rawDesc = "" +
+	"\n" +
+	"\rexample.proto\x12\x0egoption.testpb\x1a\x1egoogle/protobuf/wrappers.proto\"\xf8\x04\n" +
+	"\aExample\x12\x19\n" +
+	"\x05count\x18\x01 \x01(\x05H\x01R\x05count\x88\x01\x01\x12\x17\n" +
+	"\x04name\x18\x02 \x01(\tH\x02R\x04name\x88\x01\x01\x12\x1d\n" +
+	"\apayload\x18\x03 \x01(\fH\x03R\apayload\x88\x01\x01\x120\n" +
+	"\x05color\x18\x04 \x01(\x0e2\x15.goption.testpb.ColorH\x04R\x05color\x88\x01\x01\x12\x19\n" +
+	"\x05ratio\x18\x05 \x01(\x01H\x05R\x05ratio\x88\x01\x01\x12@\n" +
+	"\rwrapped_count\x18\x06 \x01(\v2\x1b.google.protobuf.Int32ValueR\fwrappedCount\x12?\n" +
+	"\fwrapped_name\x18\a \x01(\v2\x1c.google.protobuf.StringValueR\vwrappedName\x12D\n" +
+	"\x0fwrapped_payload\x18\b \x01(\v2\x1b.google.protobuf.BytesValueR\x0ewrappedPayload\x12\x14\n" +
+	"\x05plain\x18\t \x01(\x05R\x05plain\x12\x12\n" +
+	"\x04list\x18\n" +
+	" \x03(\x05R\x04list\x126\n" +
+	"\x06nested\x18\v \x01(\v2\x1e.goption.testpb.Example.NestedR\x06nested\x12\x16\n" +
+	"\x05first\x18\f \x01(\x05H\x00R\x05first\x12\x18\n" +
+	"\x06second\x18\r \x01(\tH\x00R\x06second\x1a3\n" +
+	"\x06Nested\x12\x1d\n" +
+	"\aenabled\x18\x01 \x01(\bH\x00R\aenabled\x88\x01\x01B\n" +
+	"\n" +
+	"\b_enabledB\b\n" +
+	"\x06choiceB\b\n" +
+	"\x06_countB\a\n" +
+	"\x05_nameB\n" +
+	"\n" +
+	"\b_payloadB\b\n" +
+	"\x06_colorB\b\n" +
+	"\x06_ratio*-\n" +
+	"\x05Color\x12\x15\n" +
+	"\x11COLOR_UNSPECIFIED\x10\x00\x12\r\n" +
+	"\tCOLOR_RED\x10\x01B6Z4github.com/jordan-bonecutter/goption/internal/testpbb\x06proto3"
+
+var (
+	file_example_proto_rawDescOnce sync.Once
+	file_example_proto_rawDescData []byte
+)
+
+func file_example_proto_rawDescGZIP() []byte {
+	file_example_proto_rawDescOnce.Do(func() {
+		file_example_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_example_proto_rawDesc), len(file_example_proto_rawDesc)))
+	})
+	return file_example_proto_rawDescData
+}
+
+var file_example_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_example_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_example_proto_goTypes = []any{
+	(Color)(0),                     // 0: goption.testpb.Color
+	(*Example)(nil),                // 1: goption.testpb.Example
+	(*Example_Nested)(nil),         // 2: goption.testpb.Example.Nested
+	(*wrapperspb.Int32Value)(nil),  // 3: google.protobuf.Int32Value
+	(*wrapperspb.StringValue)(nil), // 4: google.protobuf.StringValue
+	(*wrapperspb.BytesValue)(nil),  // 5: google.protobuf.BytesValue
+}
+var file_example_proto_depIdxs = []int32{
+	0, // 0: goption.testpb.Example.color:type_name -> goption.testpb.Color
+	3, // 1: goption.testpb.Example.wrapped_count:type_name -> google.protobuf.Int32Value
+	4, // 2: goption.testpb.Example.wrapped_name:type_name -> google.protobuf.StringValue
+	5, // 3: goption.testpb.Example.wrapped_payload:type_name -> google.protobuf.BytesValue
+	2, // 4: goption.testpb.Example.nested:type_name -> goption.testpb.Example.Nested
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_example_proto_init() }
+func file_example_proto_init() {
+	if File_example_proto != nil {
+		return
+	}
+	file_example_proto_msgTypes[0].OneofWrappers = []any{
+		(*Example_First)(nil),
+		(*Example_Second)(nil),
+	}
+	file_example_proto_msgTypes[1].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_example_proto_rawDesc), len(file_example_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_example_proto_goTypes,
+		DependencyIndexes: file_example_proto_depIdxs,
+		EnumInfos:         file_example_proto_enumTypes,
+		MessageInfos:      file_example_proto_msgTypes,
+	}.Build()
+	File_example_proto = out.File
+	file_example_proto_goTypes = nil
+	file_example_proto_depIdxs = nil
+}
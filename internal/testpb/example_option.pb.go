@@ -0,0 +1,127 @@
+// Code generated by protoc-gen-go-option. DO NOT EDIT.
+// source: example.proto
+
+package testpb
+
+import (
+	goption "github.com/jordan-bonecutter/goption"
+	protooption "github.com/jordan-bonecutter/goption/protooption"
+	wrapperspb "google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// GetCountOption returns the count field as an Option.
+func (x *Example) GetCountOption() goption.Option[int32] {
+	if x == nil {
+		return goption.None[int32]()
+	}
+	return protooption.FromPointer(x.Count)
+}
+
+// SetCountOption sets the count field from an Option.
+func (x *Example) SetCountOption(o goption.Option[int32]) {
+	x.Count = protooption.ToPointer(o)
+}
+
+// GetNameOption returns the name field as an Option.
+func (x *Example) GetNameOption() goption.Option[string] {
+	if x == nil {
+		return goption.None[string]()
+	}
+	return protooption.FromPointer(x.Name)
+}
+
+// SetNameOption sets the name field from an Option.
+func (x *Example) SetNameOption(o goption.Option[string]) {
+	x.Name = protooption.ToPointer(o)
+}
+
+// GetPayloadOption returns the payload field as an Option.
+func (x *Example) GetPayloadOption() goption.Option[[]byte] {
+	if x == nil {
+		return goption.None[[]byte]()
+	}
+	return protooption.FromBytes(x.Payload)
+}
+
+// SetPayloadOption sets the payload field from an Option.
+func (x *Example) SetPayloadOption(o goption.Option[[]byte]) {
+	x.Payload = protooption.ToBytes(o)
+}
+
+// GetColorOption returns the color field as an Option.
+func (x *Example) GetColorOption() goption.Option[Color] {
+	if x == nil {
+		return goption.None[Color]()
+	}
+	return protooption.FromPointer(x.Color)
+}
+
+// SetColorOption sets the color field from an Option.
+func (x *Example) SetColorOption(o goption.Option[Color]) {
+	x.Color = protooption.ToPointer(o)
+}
+
+// GetRatioOption returns the ratio field as an Option.
+func (x *Example) GetRatioOption() goption.Option[float64] {
+	if x == nil {
+		return goption.None[float64]()
+	}
+	return protooption.FromPointer(x.Ratio)
+}
+
+// SetRatioOption sets the ratio field from an Option.
+func (x *Example) SetRatioOption(o goption.Option[float64]) {
+	x.Ratio = protooption.ToPointer(o)
+}
+
+// GetWrappedCountOption returns the wrapped_count field as an Option.
+func (x *Example) GetWrappedCountOption() goption.Option[int32] {
+	if x == nil {
+		return goption.None[int32]()
+	}
+	return protooption.FromWrapper(x.GetWrappedCount())
+}
+
+// SetWrappedCountOption sets the wrapped_count field from an Option.
+func (x *Example) SetWrappedCountOption(o goption.Option[int32]) {
+	x.WrappedCount = protooption.ToWrapper(o, wrapperspb.Int32)
+}
+
+// GetWrappedNameOption returns the wrapped_name field as an Option.
+func (x *Example) GetWrappedNameOption() goption.Option[string] {
+	if x == nil {
+		return goption.None[string]()
+	}
+	return protooption.FromWrapper(x.GetWrappedName())
+}
+
+// SetWrappedNameOption sets the wrapped_name field from an Option.
+func (x *Example) SetWrappedNameOption(o goption.Option[string]) {
+	x.WrappedName = protooption.ToWrapper(o, wrapperspb.String)
+}
+
+// GetWrappedPayloadOption returns the wrapped_payload field as an Option.
+func (x *Example) GetWrappedPayloadOption() goption.Option[[]byte] {
+	if x == nil {
+		return goption.None[[]byte]()
+	}
+	return protooption.FromWrapper(x.GetWrappedPayload())
+}
+
+// SetWrappedPayloadOption sets the wrapped_payload field from an Option.
+func (x *Example) SetWrappedPayloadOption(o goption.Option[[]byte]) {
+	x.WrappedPayload = protooption.ToWrapper(o, wrapperspb.Bytes)
+}
+
+// GetEnabledOption returns the enabled field as an Option.
+func (x *Example_Nested) GetEnabledOption() goption.Option[bool] {
+	if x == nil {
+		return goption.None[bool]()
+	}
+	return protooption.FromPointer(x.Enabled)
+}
+
+// SetEnabledOption sets the enabled field from an Option.
+func (x *Example_Nested) SetEnabledOption(o goption.Option[bool]) {
+	x.Enabled = protooption.ToPointer(o)
+}
@@ -0,0 +1,84 @@
+// Package wiretest checks that the options of an encoder subpackage are
+// encoded exactly like pointers.
+package wiretest
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jordan-bonecutter/goption/optiontest"
+)
+
+// Inner is a struct held by Pointers.
+type Inner struct {
+	Name string
+}
+
+// Pointers holds the pointer fields which options are compared against.
+// N is the subpackage's Option[int].
+type Pointers[N any] struct {
+	Int    *int
+	String *string
+	Inner  *Inner
+	Time   *time.Time
+	Bytes  *[]byte
+	Nested *N
+}
+
+// Compatible checks that, for a set of Pointers, the options returned by
+// toOptions are encoded with c to the same bytes, and that those bytes decode
+// back to equal options. Decoding is done into options which are all present,
+// so that nil is checked to reset them. someZero is Some(0) as an N.
+func Compatible[N, O any](t *testing.T, c optiontest.Codec, someZero N, toOptions func(Pointers[N]) O) {
+	t.Helper()
+	now := time.Unix(1704164645, 0)
+	some := Pointers[N]{
+		Int:    ptr(3),
+		String: ptr("hey!"),
+		Inner:  &Inner{Name: "inner"},
+		Time:   &now,
+		Bytes:  ptr([]byte{1, 2, 3}),
+		Nested: &someZero,
+	}
+
+	for _, tt := range []struct {
+		name     string
+		pointers Pointers[N]
+	}{
+		{"none", Pointers[N]{}},
+		{"some", some},
+		{"zero values", Pointers[N]{Int: ptr(0), String: ptr("")}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			expected, err := c.Marshal(tt.pointers)
+			if err != nil {
+				t.Fatalf("Failed marshalling pointers: %s", err)
+			}
+
+			options := toOptions(tt.pointers)
+			encoded, err := c.Marshal(options)
+			if err != nil {
+				t.Fatalf("Failed marshalling options: %s", err)
+			}
+
+			if !bytes.Equal(encoded, expected) {
+				t.Errorf("Expected %x, got %x", expected, encoded)
+			}
+
+			decoded := toOptions(some)
+			if err := c.Unmarshal(expected, &decoded); err != nil {
+				t.Fatalf("Failed unmarshalling options: %s", err)
+			}
+
+			if diff := cmp.Diff(options, decoded, optiontest.EquateOptions()); diff != "" {
+				t.Errorf("Unexpected decoded options:\n%s", diff)
+			}
+		})
+	}
+}
+
+func ptr[T any](t T) *T {
+	return &t
+}
@@ -0,0 +1,184 @@
+package goption
+
+import (
+	"cmp"
+	"iter"
+)
+
+// Somes yields the underlying value of every present optional in seq,
+// skipping the empty ones.
+func Somes[T any](seq iter.Seq[Option[T]]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for o := range seq {
+			if o.ok && !yield(o.t) {
+				return
+			}
+		}
+	}
+}
+
+// Somes2 is like Somes but for iter.Seq2, keeping the key of every present value.
+func Somes2[K, V any](seq iter.Seq2[K, Option[V]]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, o := range seq {
+			if o.ok && !yield(k, o.t) {
+				return
+			}
+		}
+	}
+}
+
+// FilterMap applies f to every value in seq and yields the present results.
+func FilterMap[T, U any](seq iter.Seq[T], f func(T) Option[U]) iter.Seq[U] {
+	return func(yield func(U) bool) {
+		for t := range seq {
+			if o := f(t); o.ok && !yield(o.t) {
+				return
+			}
+		}
+	}
+}
+
+// FilterMap2 is like FilterMap but for iter.Seq2.
+func FilterMap2[K, V, U any](seq iter.Seq2[K, V], f func(K, V) Option[U]) iter.Seq2[K, U] {
+	return func(yield func(K, U) bool) {
+		for k, v := range seq {
+			if o := f(k, v); o.ok && !yield(k, o.t) {
+				return
+			}
+		}
+	}
+}
+
+// First returns the first value in seq, or none if seq is empty.
+func First[T any](seq iter.Seq[T]) Option[T] {
+	for t := range seq {
+		return Some(t)
+	}
+
+	return None[T]()
+}
+
+// Last returns the last value in seq, or none if seq is empty.
+func Last[T any](seq iter.Seq[T]) Option[T] {
+	last := None[T]()
+	for t := range seq {
+		last = Some(t)
+	}
+
+	return last
+}
+
+// Nth returns the zero indexed nth value in seq, or none if seq is too short.
+func Nth[T any](seq iter.Seq[T], n int) Option[T] {
+	if n < 0 {
+		return None[T]()
+	}
+
+	i := 0
+	for t := range seq {
+		if i == n {
+			return Some(t)
+		}
+		i++
+	}
+
+	return None[T]()
+}
+
+// Pair is a key and value yielded by an iter.Seq2.
+type Pair[K, V any] struct {
+	Key   K
+	Value V
+}
+
+// First2 is like First but for iter.Seq2, returning the first key and value.
+func First2[K, V any](seq iter.Seq2[K, V]) Option[Pair[K, V]] {
+	for k, v := range seq {
+		return Some(Pair[K, V]{Key: k, Value: v})
+	}
+
+	return None[Pair[K, V]]()
+}
+
+// Find returns the first value in seq satisfying pred, or none if there isn't one.
+func Find[T any](seq iter.Seq[T], pred func(T) bool) Option[T] {
+	for t := range seq {
+		if pred(t) {
+			return Some(t)
+		}
+	}
+
+	return None[T]()
+}
+
+// Find2 is like Find but for iter.Seq2, returning the first key and value
+// satisfying pred.
+func Find2[K, V any](seq iter.Seq2[K, V], pred func(K, V) bool) Option[Pair[K, V]] {
+	for k, v := range seq {
+		if pred(k, v) {
+			return Some(Pair[K, V]{Key: k, Value: v})
+		}
+	}
+
+	return None[Pair[K, V]]()
+}
+
+// Max returns the largest value in seq, or none if seq is empty.
+// Like slices.Max, the result is NaN if any floating point value is NaN.
+func Max[T cmp.Ordered](seq iter.Seq[T]) Option[T] {
+	return Reduce(seq, func(a, b T) T {
+		return max(a, b)
+	})
+}
+
+// Min returns the smallest value in seq, or none if seq is empty.
+// Like slices.Min, the result is NaN if any floating point value is NaN.
+func Min[T cmp.Ordered](seq iter.Seq[T]) Option[T] {
+	return Reduce(seq, func(a, b T) T {
+		return min(a, b)
+	})
+}
+
+// Reduce combines the values in seq from left to right using f, starting
+// with the first value. If seq is empty none is returned.
+func Reduce[T any](seq iter.Seq[T], f func(T, T) T) Option[T] {
+	acc := None[T]()
+	for t := range seq {
+		if !acc.ok {
+			acc = Some(t)
+			continue
+		}
+		acc.t = f(acc.t, t)
+	}
+
+	return acc
+}
+
+// All collects the underlying values of seq into a slice.
+// If any optional in seq is empty, iteration stops and none is returned.
+func All[T any](seq iter.Seq[Option[T]]) Option[[]T] {
+	all := []T{}
+	for o := range seq {
+		if !o.ok {
+			return None[[]T]()
+		}
+		all = append(all, o.t)
+	}
+
+	return Some(all)
+}
+
+// All2 is like All but for iter.Seq2, collecting every key along with its
+// underlying value in order.
+func All2[K, V any](seq iter.Seq2[K, Option[V]]) Option[[]Pair[K, V]] {
+	all := []Pair[K, V]{}
+	for k, o := range seq {
+		if !o.ok {
+			return None[[]Pair[K, V]]()
+		}
+		all = append(all, Pair[K, V]{Key: k, Value: o.t})
+	}
+
+	return Some(all)
+}
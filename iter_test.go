@@ -0,0 +1,200 @@
+package goption
+
+import (
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestSomes(t *testing.T) {
+	seq := slices.Values([]Option[int]{Some(1), None[int](), Some(3)})
+	got := slices.Collect(Somes(seq))
+	if !slices.Equal(got, []int{1, 3}) {
+		t.Errorf("Expected [1 3], got %v", got)
+	}
+
+	for range Somes(seq) {
+		break
+	}
+}
+
+func TestSomes2(t *testing.T) {
+	seq := maps.All(map[string]Option[int]{"a": Some(1), "b": None[int]()})
+	got := maps.Collect(Somes2(seq))
+	if len(got) != 1 || got["a"] != 1 {
+		t.Errorf("Expected map[a:1], got %v", got)
+	}
+}
+
+func TestFilterMap(t *testing.T) {
+	parse := func(s string) Option[int] {
+		v, err := strconv.Atoi(s)
+		if err != nil {
+			return None[int]()
+		}
+		return Some(v)
+	}
+
+	got := slices.Collect(FilterMap(slices.Values([]string{"1", "two", "3"}), parse))
+	if !slices.Equal(got, []int{1, 3}) {
+		t.Errorf("Expected [1 3], got %v", got)
+	}
+}
+
+func TestFilterMap2(t *testing.T) {
+	evens := func(i, v int) Option[int] {
+		if v%2 != 0 {
+			return None[int]()
+		}
+		return Some(v * 10)
+	}
+
+	got := maps.Collect(FilterMap2(slices.All([]int{1, 2, 3, 4}), evens))
+	if len(got) != 2 || got[1] != 20 || got[3] != 40 {
+		t.Errorf("Expected map[1:20 3:40], got %v", got)
+	}
+}
+
+func TestFirstLast(t *testing.T) {
+	seq := slices.Values([]int{4, 5, 6})
+	if first := First(seq); first.UnwrapOr(-1) != 4 {
+		t.Errorf("Expected first to be 4, got %v", first)
+	}
+	if last := Last(seq); last.UnwrapOr(-1) != 6 {
+		t.Errorf("Expected last to be 6, got %v", last)
+	}
+
+	empty := slices.Values([]int{})
+	if First(empty).Ok() || Last(empty).Ok() {
+		t.Errorf("Expected empty sequence to yield none")
+	}
+}
+
+func TestNth(t *testing.T) {
+	seq := slices.Values([]string{"a", "b", "c"})
+	if nth := Nth(seq, 1); nth.UnwrapOr("") != "b" {
+		t.Errorf("Expected b, got %v", nth)
+	}
+	if nth := Nth(seq, 3); nth.Ok() {
+		t.Errorf("Expected none past the end, got %v", nth)
+	}
+	if nth := Nth(seq, -1); nth.Ok() {
+		t.Errorf("Expected none for negative index, got %v", nth)
+	}
+}
+
+func TestFind(t *testing.T) {
+	seq := slices.Values([]int{1, 2, 3, 4})
+	if found := Find(seq, func(v int) bool { return v > 2 }); found.UnwrapOr(-1) != 3 {
+		t.Errorf("Expected 3, got %v", found)
+	}
+	if found := Find(seq, func(v int) bool { return v > 4 }); found.Ok() {
+		t.Errorf("Expected none, got %v", found)
+	}
+}
+
+func TestMaxMin(t *testing.T) {
+	seq := slices.Values([]int{3, 1, 4, 1, 5})
+	if largest := Max(seq); largest.UnwrapOr(-1) != 5 {
+		t.Errorf("Expected 5, got %v", largest)
+	}
+	if smallest := Min(seq); smallest.UnwrapOr(-1) != 1 {
+		t.Errorf("Expected 1, got %v", smallest)
+	}
+
+	empty := slices.Values([]int{})
+	if Max(empty).Ok() || Min(empty).Ok() {
+		t.Errorf("Expected empty sequence to yield none")
+	}
+}
+
+func TestMaxMinNaN(t *testing.T) {
+	for _, values := range [][]float64{
+		{math.NaN(), 1},
+		{1, math.NaN()},
+		{1, math.NaN(), 2},
+	} {
+		if largest := Max(slices.Values(values)); !math.IsNaN(largest.UnwrapOr(0)) || !math.IsNaN(slices.Max(values)) {
+			t.Errorf("Expected NaN for the max of %v, got %v", values, largest)
+		}
+		if smallest := Min(slices.Values(values)); !math.IsNaN(smallest.UnwrapOr(0)) || !math.IsNaN(slices.Min(values)) {
+			t.Errorf("Expected NaN for the min of %v, got %v", values, smallest)
+		}
+	}
+}
+
+func TestReduce(t *testing.T) {
+	sum := func(a, b int) int { return a + b }
+	if total := Reduce(slices.Values([]int{1, 2, 3}), sum); total.UnwrapOr(-1) != 6 {
+		t.Errorf("Expected 6, got %v", total)
+	}
+	if total := Reduce(slices.Values([]int{7}), sum); total.UnwrapOr(-1) != 7 {
+		t.Errorf("Expected 7, got %v", total)
+	}
+	if total := Reduce(slices.Values([]int{}), sum); total.Ok() {
+		t.Errorf("Expected none, got %v", total)
+	}
+}
+
+func TestAll(t *testing.T) {
+	all := All(slices.Values([]Option[int]{Some(1), Some(2)}))
+	if !slices.Equal(all.UnwrapOrDefault(), []int{1, 2}) {
+		t.Errorf("Expected [1 2], got %v", all)
+	}
+
+	visited := 0
+	seq := func(yield func(Option[int]) bool) {
+		for _, o := range []Option[int]{Some(1), None[int](), Some(3)} {
+			visited++
+			if !yield(o) {
+				return
+			}
+		}
+	}
+	if all := All(seq); all.Ok() {
+		t.Errorf("Expected none, got %v", all)
+	}
+	if visited != 2 {
+		t.Errorf("Expected All to stop at the first none, visited %d", visited)
+	}
+
+	if empty := All(slices.Values([]Option[int]{})); !empty.Ok() || len(empty.Unwrap()) != 0 {
+		t.Errorf("Expected some empty slice, got %v", empty)
+	}
+}
+
+func TestFirst2(t *testing.T) {
+	if first := First2(slices.All([]string{"a", "b"})); first != Some(Pair[int, string]{Key: 0, Value: "a"}) {
+		t.Errorf("Expected (0, a), got %v", first)
+	}
+	if first := First2(slices.All([]string{})); first.Ok() {
+		t.Errorf("Expected none, got %v", first)
+	}
+}
+
+func TestFind2(t *testing.T) {
+	seq := slices.All([]int{5, 6, 7})
+	if found := Find2(seq, func(i, v int) bool { return v%2 == 0 }); found != Some(Pair[int, int]{Key: 1, Value: 6}) {
+		t.Errorf("Expected (1, 6), got %v", found)
+	}
+	if found := Find2(seq, func(i, v int) bool { return v > 7 }); found.Ok() {
+		t.Errorf("Expected none, got %v", found)
+	}
+}
+
+func TestAll2(t *testing.T) {
+	all := All2(slices.All([]Option[string]{Some("a"), Some("b")}))
+	expected := []Pair[int, string]{{0, "a"}, {1, "b"}}
+	if !slices.Equal(all.UnwrapOrDefault(), expected) {
+		t.Errorf("Expected %v, got %v", expected, all)
+	}
+
+	if all := All2(slices.All([]Option[string]{Some("a"), None[string]()})); all.Ok() {
+		t.Errorf("Expected none, got %v", all)
+	}
+	if all := All2(slices.All([]Option[string]{})); !all.Ok() || len(all.Unwrap()) != 0 {
+		t.Errorf("Expected an empty slice, got %v", all)
+	}
+}
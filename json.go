@@ -2,6 +2,11 @@ package goption
 
 import (
 	"encoding/json"
+	"math"
+	"slices"
+	"strconv"
+	"time"
+	"unicode/utf8"
 )
 
 // MarshalJSON marshals the underlying option data
@@ -10,6 +15,10 @@ func (o Option[T]) MarshalJSON() ([]byte, error) {
 		return []byte("null"), nil
 	}
 
+	if data, ok := marshalJSON(&o.t); ok {
+		return data, nil
+	}
+
 	return json.Marshal(o.t)
 }
 
@@ -21,5 +30,256 @@ func (o *Option[T]) UnmarshalJSON(data []byte) error {
 	}
 
 	o.ok = true
+	if parseJSON(data, &o.t) {
+		return nil
+	}
+
 	return json.Unmarshal(data, &o.t)
 }
+
+// marshalJSON encodes *p without going through reflection if *p is a string,
+// number, bool or time.Time.
+// It returns false if the generic encoder must be used instead.
+func marshalJSON(p any) ([]byte, bool) {
+	var b []byte
+	switch v := p.(type) {
+	case *string:
+		return appendJSONString(b, *v)
+	case *int:
+		return strconv.AppendInt(b, int64(*v), 10), true
+	case *int8:
+		return strconv.AppendInt(b, int64(*v), 10), true
+	case *int16:
+		return strconv.AppendInt(b, int64(*v), 10), true
+	case *int32:
+		return strconv.AppendInt(b, int64(*v), 10), true
+	case *int64:
+		return strconv.AppendInt(b, *v, 10), true
+	case *uint:
+		return strconv.AppendUint(b, uint64(*v), 10), true
+	case *uint8:
+		return strconv.AppendUint(b, uint64(*v), 10), true
+	case *uint16:
+		return strconv.AppendUint(b, uint64(*v), 10), true
+	case *uint32:
+		return strconv.AppendUint(b, uint64(*v), 10), true
+	case *uint64:
+		return strconv.AppendUint(b, *v, 10), true
+	case *float32:
+		return appendJSONFloat(b, float64(*v), 32)
+	case *float64:
+		return appendJSONFloat(b, *v, 64)
+	case *bool:
+		return strconv.AppendBool(b, *v), true
+	case *time.Time:
+		data, err := v.MarshalJSON()
+		return data, err == nil
+	}
+
+	return nil, false
+}
+
+// appendJSONString appends s as a JSON string if it only holds printable
+// ASCII characters which encoding/json doesn't escape.
+func appendJSONString(b []byte, s string) ([]byte, bool) {
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; c < 0x20 || c >= utf8.RuneSelf || c == '"' || c == '\\' || c == '<' || c == '>' || c == '&' {
+			return nil, false
+		}
+	}
+
+	b = append(slices.Grow(b, len(s)+2), '"')
+	b = append(b, s...)
+	return append(b, '"'), true
+}
+
+// appendJSONFloat appends f the way encoding/json formats floats.
+func appendJSONFloat(b []byte, f float64, bits int) ([]byte, bool) {
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		return nil, false
+	}
+
+	format := byte('f')
+	if abs := math.Abs(f); abs != 0 {
+		if bits == 64 && (abs < 1e-6 || abs >= 1e21) || bits == 32 && (float32(abs) < 1e-6 || float32(abs) >= 1e21) {
+			format = 'e'
+		}
+	}
+
+	b = strconv.AppendFloat(slices.Grow(b, 24), f, format, -1, bits)
+	if format == 'e' {
+		// Clean up e-09 to e-9.
+		if n := len(b); n >= 4 && b[n-4] == 'e' && b[n-3] == '-' && b[n-2] == '0' {
+			b[n-2] = b[n-1]
+			b = b[:n-1]
+		}
+	}
+
+	return b, true
+}
+
+// parseJSON decodes data into *p without going through reflection if *p is
+// a string, number, bool or time.Time.
+// It returns false if the generic decoder must be used instead, which is also
+// the case for invalid input so that errors match encoding/json.
+func parseJSON(data []byte, p any) bool {
+	switch v := p.(type) {
+	case *string:
+		if len(data) < 2 || data[0] != '"' || data[len(data)-1] != '"' {
+			return false
+		}
+		for _, c := range data[1 : len(data)-1] {
+			if c < 0x20 || c == '"' || c == '\\' {
+				return false
+			}
+		}
+		if !utf8.Valid(data) {
+			return false
+		}
+		*v = string(data[1 : len(data)-1])
+		return true
+	case *int:
+		return parseJSONInt(data, v, strconv.IntSize)
+	case *int8:
+		return parseJSONInt(data, v, 8)
+	case *int16:
+		return parseJSONInt(data, v, 16)
+	case *int32:
+		return parseJSONInt(data, v, 32)
+	case *int64:
+		return parseJSONInt(data, v, 64)
+	case *uint:
+		return parseJSONUint(data, v, strconv.IntSize)
+	case *uint8:
+		return parseJSONUint(data, v, 8)
+	case *uint16:
+		return parseJSONUint(data, v, 16)
+	case *uint32:
+		return parseJSONUint(data, v, 32)
+	case *uint64:
+		return parseJSONUint(data, v, 64)
+	case *float32:
+		return parseJSONFloat(data, v, 32)
+	case *float64:
+		return parseJSONFloat(data, v, 64)
+	case *bool:
+		switch string(data) {
+		case "true":
+			*v = true
+			return true
+		case "false":
+			*v = false
+			return true
+		}
+	case *time.Time:
+		return v.UnmarshalJSON(data) == nil
+	}
+
+	return false
+}
+
+func parseJSONInt[T int | int8 | int16 | int32 | int64](data []byte, p *T, bits int) bool {
+	if !isJSONInteger(data) {
+		return false
+	}
+
+	n, err := strconv.ParseInt(string(data), 10, bits)
+	if err != nil {
+		return false
+	}
+
+	*p = T(n)
+	return true
+}
+
+func parseJSONUint[T uint | uint8 | uint16 | uint32 | uint64](data []byte, p *T, bits int) bool {
+	if !isJSONInteger(data) || data[0] == '-' {
+		return false
+	}
+
+	n, err := strconv.ParseUint(string(data), 10, bits)
+	if err != nil {
+		return false
+	}
+
+	*p = T(n)
+	return true
+}
+
+func parseJSONFloat[T float32 | float64](data []byte, p *T, bits int) bool {
+	if !isJSONNumber(data) {
+		return false
+	}
+
+	f, err := strconv.ParseFloat(string(data), bits)
+	if err != nil {
+		return false
+	}
+
+	*p = T(f)
+	return true
+}
+
+// isJSONInteger returns true if data is a JSON number without a fraction or
+// exponent.
+func isJSONInteger(data []byte) bool {
+	return scanJSONInteger(data) == len(data) && len(data) > 0
+}
+
+// scanJSONInteger returns the length of the JSON integer at the start of
+// data, or -1 if there isn't one.
+func scanJSONInteger(data []byte) int {
+	i := 0
+	if i < len(data) && data[i] == '-' {
+		i++
+	}
+
+	switch {
+	case i >= len(data):
+		return -1
+	case data[i] == '0':
+		return i + 1
+	case data[i] < '1' || data[i] > '9':
+		return -1
+	}
+
+	for i < len(data) && data[i] >= '0' && data[i] <= '9' {
+		i++
+	}
+	return i
+}
+
+// isJSONNumber returns true if data is a JSON number.
+func isJSONNumber(data []byte) bool {
+	i := scanJSONInteger(data)
+	if i < 0 {
+		return false
+	}
+
+	if i < len(data) && data[i] == '.' {
+		i++
+		start := i
+		for i < len(data) && data[i] >= '0' && data[i] <= '9' {
+			i++
+		}
+		if i == start {
+			return false
+		}
+	}
+
+	if i < len(data) && (data[i] == 'e' || data[i] == 'E') {
+		i++
+		if i < len(data) && (data[i] == '+' || data[i] == '-') {
+			i++
+		}
+		start := i
+		for i < len(data) && data[i] >= '0' && data[i] <= '9' {
+			i++
+		}
+		if i == start {
+			return false
+		}
+	}
+
+	return i == len(data)
+}
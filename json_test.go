@@ -2,7 +2,9 @@ package goption
 
 import (
 	"encoding/json"
+	"math"
 	"testing"
+	"time"
 )
 
 type Bar struct {
@@ -56,3 +58,182 @@ func TestJSONUnmarshal(t *testing.T) {
 		t.Errorf("Expected optional value to be present.")
 	}
 }
+
+// testJSONPrimitive checks that Option[T] encodes and decodes each value
+// exactly like encoding/json does for T.
+func testJSONPrimitive[T comparable](t *testing.T, values ...T) {
+	t.Helper()
+	for _, v := range values {
+		expected, expectedErr := json.Marshal(v)
+		encoded, err := Some(v).MarshalJSON()
+		if (err == nil) != (expectedErr == nil) {
+			t.Errorf("Unexpected error marshalling %#v: %v, expected %v", v, err, expectedErr)
+		} else if string(encoded) != string(expected) {
+			t.Errorf("Unexpected encoding for %#v: %s, expected %s", v, encoded, expected)
+		}
+	}
+}
+
+// testJSONParse checks that Option[T] decodes each input exactly like
+// encoding/json does for T.
+func testJSONParse[T comparable](t *testing.T, inputs ...string) {
+	t.Helper()
+	for _, input := range inputs {
+		var expected T
+		expectedErr := json.Unmarshal([]byte(input), &expected)
+
+		var decoded Option[T]
+		err := json.Unmarshal([]byte(input), &decoded)
+		if (err == nil) != (expectedErr == nil) {
+			t.Errorf("Unexpected error unmarshalling %q into %T: %v, expected %v", input, expected, err, expectedErr)
+		} else if err == nil && decoded.Unwrap() != expected {
+			t.Errorf("Unexpected value unmarshalling %q into %T: %v, expected %v", input, expected, decoded.Unwrap(), expected)
+		}
+	}
+}
+
+func TestJSONMarshalPrimitives(t *testing.T) {
+	testJSONPrimitive(t, "", "hey!", "quote\"", "back\\slash", "<html>", "a&b", "tab\t", "héllo", "\u2028", "\xff")
+	testJSONPrimitive(t, 0, 1, -1, 255, 256, math.MaxInt, math.MinInt)
+	testJSONPrimitive(t, int8(math.MinInt8), int8(math.MaxInt8))
+	testJSONPrimitive(t, int16(math.MinInt16), int16(math.MaxInt16))
+	testJSONPrimitive(t, int32(math.MinInt32), int32(math.MaxInt32))
+	testJSONPrimitive(t, int64(math.MinInt64), int64(math.MaxInt64))
+	testJSONPrimitive(t, uint(0), uint(math.MaxUint))
+	testJSONPrimitive(t, uint8(0), uint8(math.MaxUint8))
+	testJSONPrimitive(t, uint16(0), uint16(math.MaxUint16))
+	testJSONPrimitive(t, uint32(0), uint32(math.MaxUint32))
+	testJSONPrimitive(t, uint64(0), uint64(math.MaxUint64))
+	testJSONPrimitive(t, 0.0, math.Copysign(0, -1), 1.5, -2.25, 1e-6, 1e-7, 1e20, 1e21, 123456789.123,
+		math.MaxFloat64, math.SmallestNonzeroFloat64, math.Inf(1), math.NaN())
+	testJSONPrimitive(t, float32(0), float32(0.1), float32(1e-7), float32(1e21), float32(math.MaxFloat32), float32(math.Inf(-1)))
+	testJSONPrimitive(t, true, false)
+	testJSONPrimitive(t, time.Time{}, time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC),
+		time.Date(2024, 1, 2, 3, 4, 5, 0, time.FixedZone("", 3600)), time.Date(10000, 1, 1, 0, 0, 0, 0, time.UTC))
+}
+
+func TestJSONUnmarshalPrimitives(t *testing.T) {
+	testJSONParse[string](t, `""`, `"hey!"`, `"héllo"`, `"quote\""`, `"\u00e9"`, "\"\xff\"", "\"tab\t\"", `1`, `"unterminated`)
+	testJSONParse[int](t, `0`, `-0`, `1`, `-1`, `9223372036854775807`, `-9223372036854775808`, `9223372036854775808`,
+		`01`, `+1`, `1.0`, `1e2`, `-`, `""`, `"1"`, `true`)
+	testJSONParse[int8](t, `127`, `-128`, `128`, `-129`)
+	testJSONParse[int16](t, `32767`, `-32768`, `32768`)
+	testJSONParse[int32](t, `2147483647`, `-2147483648`, `2147483648`)
+	testJSONParse[int64](t, `9223372036854775807`, `-9223372036854775808`, `9223372036854775808`)
+	testJSONParse[uint](t, `0`, `18446744073709551615`, `18446744073709551616`, `-1`, `-0`)
+	testJSONParse[uint8](t, `255`, `256`, `-1`)
+	testJSONParse[uint16](t, `65535`, `65536`)
+	testJSONParse[uint32](t, `4294967295`, `4294967296`)
+	testJSONParse[uint64](t, `18446744073709551615`, `18446744073709551616`)
+	testJSONParse[float64](t, `0`, `-0`, `1.5`, `-2.25e-3`, `1E21`, `1e+2`, `1e400`, `.5`, `1.`, `1e`, `01.5`, `"1.5"`, `NaN`)
+	testJSONParse[float32](t, `0.1`, `3.4028235e38`, `1e39`)
+	testJSONParse[bool](t, `true`, `false`, `True`, `1`, `"true"`)
+	testJSONParse[time.Time](t, `"2024-01-02T03:04:05.000000006Z"`, `"2024-01-02T03:04:05+01:00"`, `"2024-01-02"`, `0`)
+}
+
+func TestJSONMarshalAllocs(t *testing.T) {
+	for name, marshal := range map[string]func() ([]byte, error){
+		"int":     Some(123456).MarshalJSON,
+		"uint64":  Some(uint64(123456)).MarshalJSON,
+		"float64": Some(1.5).MarshalJSON,
+		"bool":    Some(true).MarshalJSON,
+		"string":  Some("hey!").MarshalJSON,
+		"time":    Some(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)).MarshalJSON,
+	} {
+		allocs := testing.AllocsPerRun(100, func() {
+			if _, err := marshal(); err != nil {
+				t.Fatal(err)
+			}
+		})
+		if allocs > 1 {
+			t.Errorf("Expected marshalling %s to allocate at most once, got %v", name, allocs)
+		}
+	}
+}
+
+func TestJSONUnmarshalAllocs(t *testing.T) {
+	var (
+		i  Option[int]
+		u  Option[uint64]
+		f  Option[float64]
+		b  Option[bool]
+		s  Option[string]
+		tm Option[time.Time]
+	)
+	for _, test := range []struct {
+		name   string
+		data   string
+		opt    json.Unmarshaler
+		allocs float64
+	}{
+		{"int", `123456`, &i, 0},
+		{"uint64", `123456`, &u, 0},
+		{"float64", `1.5`, &f, 0},
+		{"bool", `true`, &b, 0},
+		{"string", `"hey!"`, &s, 1},
+		{"time", `"2024-01-02T03:04:05Z"`, &tm, 0},
+	} {
+		data := []byte(test.data)
+		allocs := testing.AllocsPerRun(100, func() {
+			if err := test.opt.UnmarshalJSON(data); err != nil {
+				t.Fatal(err)
+			}
+		})
+		if allocs > test.allocs {
+			t.Errorf("Expected unmarshalling %s to allocate at most %v times, got %v", test.name, test.allocs, allocs)
+		}
+	}
+}
+
+func BenchmarkJSONMarshal(b *testing.B) {
+	for name, marshal := range map[string]func() ([]byte, error){
+		"int":     Some(123456).MarshalJSON,
+		"float64": Some(1.5).MarshalJSON,
+		"bool":    Some(true).MarshalJSON,
+		"string":  Some("hey!").MarshalJSON,
+		"time":    Some(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)).MarshalJSON,
+		"struct":  Some(Bar{Baz: "hey!"}).MarshalJSON,
+	} {
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := marshal(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkJSONUnmarshal(b *testing.B) {
+	var (
+		i   Option[int]
+		f   Option[float64]
+		bl  Option[bool]
+		s   Option[string]
+		tm  Option[time.Time]
+		bar Option[Bar]
+	)
+	for _, test := range []struct {
+		name string
+		data string
+		opt  json.Unmarshaler
+	}{
+		{"int", `123456`, &i},
+		{"float64", `1.5`, &f},
+		{"bool", `true`, &bl},
+		{"string", `"hey!"`, &s},
+		{"time", `"2024-01-02T03:04:05Z"`, &tm},
+		{"struct", `{"Baz":"hey!"}`, &bar},
+	} {
+		data := []byte(test.data)
+		b.Run(test.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if err := test.opt.UnmarshalJSON(data); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
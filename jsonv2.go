@@ -0,0 +1,85 @@
+// The go1.27 constraint lets this file use encoding/json/v2 and
+// encoding/json/jsontext, such as jsontext.Encoder and json.MarshalEncode,
+// which need a newer language version than the module's go 1.23.
+
+//go:build go1.27 && goexperiment.jsonv2
+
+package goption
+
+import (
+	"encoding/json/jsontext"
+	jsonv2 "encoding/json/v2"
+	"slices"
+	"strings"
+)
+
+// MarshalJSONTo implements json.MarshalerTo from encoding/json/v2, streaming
+// the underlying option data straight to enc.
+func (o Option[T]) MarshalJSONTo(enc *jsontext.Encoder) error {
+	if !o.ok {
+		return enc.WriteToken(jsontext.Null)
+	}
+
+	return jsonv2.MarshalEncode(enc, o.t)
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom from encoding/json/v2,
+// streaming the underlying option data straight from dec.
+func (o *Option[T]) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	if dec.PeekKind() == 'n' {
+		if _, err := dec.ReadToken(); err != nil {
+			return err
+		}
+		o.ok = false
+		return nil
+	}
+
+	o.ok = true
+	return jsonv2.UnmarshalDecode(dec, &o.t)
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom from encoding/json/v2,
+// rejecting null.
+func (n *NonNull[T]) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	if dec.PeekKind() != 'n' {
+		return n.Option.UnmarshalJSONFrom(dec)
+	}
+
+	if _, err := dec.ReadToken(); err != nil {
+		return err
+	}
+
+	// encoding/json doesn't add the path to errors from UnmarshalJSONFrom.
+	err := nullError[T]()
+	err.Field = strings.Join(slices.Collect(dec.StackPointer().Tokens()), ".")
+	return err
+}
+
+// MarshalJSONTo implements json.MarshalerTo from encoding/json/v2 like Option.
+func (r OptRef[T]) MarshalJSONTo(enc *jsontext.Encoder) error {
+	if r.p == nil {
+		return enc.WriteToken(jsontext.Null)
+	}
+
+	return jsonv2.MarshalEncode(enc, r.p)
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom from encoding/json/v2,
+// unmarshalling into a newly allocated value like UnmarshalJSON.
+func (r *OptRef[T]) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	if dec.PeekKind() == 'n' {
+		if _, err := dec.ReadToken(); err != nil {
+			return err
+		}
+		r.p = nil
+		return nil
+	}
+
+	p := new(T)
+	if err := jsonv2.UnmarshalDecode(dec, p); err != nil {
+		return err
+	}
+
+	r.p = p
+	return nil
+}
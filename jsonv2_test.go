@@ -0,0 +1,195 @@
+// Like jsonv2.go, this needs go1.27 to call json.Marshal and json.Unmarshal
+// from encoding/json/v2.
+
+//go:build go1.27 && goexperiment.jsonv2
+
+package goption
+
+import (
+	"encoding/json"
+	jsonv2 "encoding/json/v2"
+	"errors"
+	"testing"
+)
+
+func TestJSONv2Marshal(t *testing.T) {
+	foo := Foo{
+		Things: []int{1, 2, 3},
+	}
+	encoded, err := jsonv2.Marshal(foo)
+	if err != nil {
+		t.Fatalf("Failed marshalling json: %s", err)
+	}
+
+	if string(encoded) != `{"Stuff":null,"Things":[1,2,3]}` {
+		t.Errorf("Unexpected encoded data: %s", string(encoded))
+	}
+
+	foo.Stuff = Some(Bar{Baz: "hey!"})
+	encoded, err = jsonv2.Marshal(foo)
+	if err != nil {
+		t.Fatalf("Failed marshalling json: %s", err)
+	}
+
+	if string(encoded) != `{"Stuff":{"Baz":"hey!"},"Things":[1,2,3]}` {
+		t.Errorf("Unexpected encoded data: %s", string(encoded))
+	}
+}
+
+func TestJSONv2Unmarshal(t *testing.T) {
+	var foo Foo
+	if err := jsonv2.Unmarshal([]byte(`{"Stuff":null,"Things":[1,2,3]}`), &foo); err != nil {
+		t.Errorf("Failed unmarshalling into foo: %s", err)
+	} else if len(foo.Things) != 3 || foo.Things[0] != 1 || foo.Things[1] != 2 || foo.Things[2] != 3 {
+		t.Errorf("Failed unmarshalling foo.Things: %v", foo.Things)
+	} else if foo.Stuff.Ok() {
+		t.Errorf("Expected optional value to be empty.")
+	}
+
+	if err := jsonv2.Unmarshal([]byte(`{"Stuff":{"Baz":"hey!"},"Things":[1,2,3]}`), &foo); err != nil {
+		t.Errorf("Failed unmarshalling into foo: %s", err)
+	} else if !foo.Stuff.Ok() || foo.Stuff.Unwrap().Baz != "hey!" {
+		t.Errorf("Expected optional value to be present.")
+	}
+
+	if err := jsonv2.Unmarshal([]byte(`{"Stuff":null}`), &foo); err != nil {
+		t.Errorf("Failed unmarshalling into foo: %s", err)
+	} else if foo.Stuff.Ok() {
+		t.Errorf("Expected null to reset the optional value.")
+	}
+}
+
+func TestJSONv2UnmarshalError(t *testing.T) {
+	var opt Option[int]
+	if err := jsonv2.Unmarshal([]byte(`"abc"`), &opt); err == nil {
+		t.Errorf("Expected an error unmarshalling a string into an int")
+	}
+}
+
+func TestJSONv2OmitZero(t *testing.T) {
+	type omitter struct {
+		Count Option[int] `json:"count,omitzero"`
+		Empty Option[int] `json:"empty,omitzero"`
+	}
+
+	v := omitter{Count: Some(0)}
+	for name, marshal := range map[string]func(any) ([]byte, error){
+		"v1": func(v any) ([]byte, error) { return json.Marshal(v) },
+		"v2": func(v any) ([]byte, error) { return jsonv2.Marshal(v) },
+	} {
+		encoded, err := marshal(v)
+		if err != nil {
+			t.Fatalf("Failed marshalling json with %s: %s", name, err)
+		}
+
+		if string(encoded) != `{"count":0}` {
+			t.Errorf("Unexpected encoded data with %s: %s", name, string(encoded))
+		}
+	}
+}
+
+// TestJSONv2MatchesV1 checks that v1 semantics, such as nil slices encoding
+// as null, are kept when encoding/json calls into MarshalJSONTo.
+func TestJSONv2MatchesV1(t *testing.T) {
+	encoded, err := json.Marshal(Some([]int(nil)))
+	if err != nil {
+		t.Fatalf("Failed marshalling json: %s", err)
+	}
+
+	if string(encoded) != `null` {
+		t.Errorf("Unexpected encoded data: %s", string(encoded))
+	}
+}
+
+var benchmarkFoo = Foo{
+	Stuff:  Some(Bar{Baz: "hey!"}),
+	Things: []int{1, 2, 3},
+}
+
+func BenchmarkMarshalJSONv1(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		if _, err := benchmarkFoo.Stuff.MarshalJSON(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkMarshalJSONv2(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		if _, err := jsonv2.Marshal(benchmarkFoo.Stuff); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkUnmarshalJSONv1(b *testing.B) {
+	data := []byte(`{"Baz":"hey!"}`)
+	for i := 0; i < b.N; i++ {
+		var opt Option[Bar]
+		if err := opt.UnmarshalJSON(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkUnmarshalJSONv2(b *testing.B) {
+	data := []byte(`{"Baz":"hey!"}`)
+	for i := 0; i < b.N; i++ {
+		var opt Option[Bar]
+		if err := jsonv2.Unmarshal(data, &opt); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestJSONv2NonNull(t *testing.T) {
+	var foo struct {
+		Items []struct {
+			Count NonNull[int] `json:"count"`
+		} `json:"items"`
+	}
+
+	if err := jsonv2.Unmarshal([]byte(`{"items":[{},{"count":1}]}`), &foo); err != nil {
+		t.Fatalf("Failed unmarshalling into foo: %s", err)
+	} else if foo.Items[0].Count.Ok() || foo.Items[1].Count.UnwrapOr(0) != 1 {
+		t.Errorf("Unexpected items: %v", foo.Items)
+	}
+
+	err := jsonv2.Unmarshal([]byte(`{"items":[{"count":1},{"count":null}]}`), &foo)
+
+	var typeErr *json.UnmarshalTypeError
+	if !errors.As(err, &typeErr) {
+		t.Fatalf("Expected a type error unmarshalling null, got %v", err)
+	}
+
+	if typeErr.Field != "items.1.count" {
+		t.Errorf("Unexpected path in error: %s", err)
+	}
+}
+
+func TestJSONv2OptRef(t *testing.T) {
+	type refs struct {
+		Count OptRef[int]    `json:"count"`
+		Name  OptRef[string] `json:"name,omitzero"`
+	}
+
+	v := refs{Count: FromPtr(new(int))}
+	encoded, err := jsonv2.Marshal(v)
+	if err != nil {
+		t.Fatalf("Failed marshalling json: %s", err)
+	}
+	if string(encoded) != `{"count":0}` {
+		t.Errorf("Unexpected encoded data: %s", encoded)
+	}
+
+	shared := v
+	if err := jsonv2.Unmarshal([]byte(`{"count":3,"name":null}`), &v); err != nil {
+		t.Fatalf("Failed unmarshalling json: %s", err)
+	}
+	if *v.Count.Unwrap() != 3 || v.Name.Ok() || *shared.Count.Unwrap() != 0 {
+		t.Errorf("Unexpected values: %v, %v, %v", v.Count, v.Name, shared.Count)
+	}
+	if err := jsonv2.Unmarshal([]byte(`{"count":"x"}`), &v); err == nil || *v.Count.Unwrap() != 3 {
+		t.Errorf("Expected an error leaving count alone, got %v", err)
+	}
+}
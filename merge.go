@@ -0,0 +1,255 @@
+package goption
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Change is a difference between an Option field of two structs, as found by
+// Diff. Field is named like in OptionFields.
+type Change struct {
+	Field    string
+	From, To AnyOption
+}
+
+// Added returns true if the field was empty and is now present.
+func (c Change) Added() bool {
+	return !c.From.Ok() && c.To.Ok()
+}
+
+// Removed returns true if the field was present and is now empty.
+func (c Change) Removed() bool {
+	return c.From.Ok() && !c.To.Ok()
+}
+
+// String returns a description of the change such as "Port: 80 -> 8080".
+func (c Change) String() string {
+	return fmt.Sprintf("%s: %v -> %v", c.Field, c.From, c.To)
+}
+
+// Merge overlays the present Option fields of each of srcs onto the struct
+// dst points to, so later sources take priority over earlier ones and over
+// dst, like src.Or(dst). Nested structs and pointers to structs are merged
+// field by field, and so are the values of two present Option fields whose
+// type holds Options itself. Other fields of dst are left alone.
+//
+// The sources are never modified. Pointers in dst which are merged into are
+// replaced by pointers to copies, since they may be shared with a source or
+// with the value dst was copied from.
+//
+// Merge panics if dst is not a pointer to a struct, or if a source is neither
+// a struct of the same type nor a pointer to one. Nil sources are skipped.
+// Neither dst nor the sources may contain pointer cycles.
+func Merge(dst any, srcs ...any) {
+	d := reflect.ValueOf(dst)
+	if d.Kind() != reflect.Pointer || d.IsNil() || d.Elem().Kind() != reflect.Struct {
+		panic(fmt.Sprintf("goption: Merge into %T, want a pointer to a struct", dst))
+	}
+
+	d = d.Elem()
+	for _, src := range srcs {
+		s := reflect.ValueOf(src)
+		if s.Kind() == reflect.Pointer && s.Type().Elem() == d.Type() {
+			if s.IsNil() {
+				continue
+			}
+			s = s.Elem()
+		}
+		if s.Type() != d.Type() {
+			panic(fmt.Sprintf("goption: Merge from %T into %T", src, dst))
+		}
+
+		mergeValue(d, s)
+	}
+}
+
+// mergeValue merges the options in src into dst, which have the same type.
+func mergeValue(dst, src reflect.Value) {
+	switch dst.Kind() {
+	case reflect.Pointer:
+		if src.IsNil() || !holdsOptions(dst.Type()) {
+			return
+		}
+		if dst.IsNil() {
+			if !dst.CanSet() {
+				return
+			}
+			dst.Set(reflect.New(dst.Type().Elem()))
+		} else if dst.CanSet() {
+			clone := reflect.New(dst.Type().Elem())
+			clone.Elem().Set(dst.Elem())
+			dst.Set(clone)
+		}
+		mergeValue(dst.Elem(), src.Elem())
+
+	case reflect.Struct:
+		if o, isOption := asOption(src); isOption {
+			mergeOption(dst, o)
+			return
+		}
+
+		for i := 0; i < dst.NumField(); i++ {
+			if field := dst.Type().Field(i); field.IsExported() || field.Anonymous {
+				mergeValue(dst.Field(i), src.Field(i))
+			}
+		}
+	}
+}
+
+// mergeOption merges the option src into the option dst.
+func mergeOption(dst reflect.Value, src AnyOption) {
+	srcValue, ok := src.OptionAny()
+	if !ok {
+		return
+	}
+
+	elem := src.ElemType()
+	dstValue, dstOk := dst.Interface().(AnyOption).OptionAny()
+	set := dst.Addr().Interface().(interface{ SetOptionAny(any, bool) })
+	if !dstOk || !holdsOptions(elem) {
+		// Set the value rather than copying src, so that an OptRef doesn't
+		// share it with the source.
+		set.SetOptionAny(srcValue, true)
+		return
+	}
+
+	// dst's value may have been taken wholesale from an earlier source, so
+	// merge into a copy. mergeValue copies the pointers it follows.
+	merged := typedValue(dstValue, elem)
+	mergeValue(merged, typedValue(srcValue, elem))
+	set.SetOptionAny(merged.Interface(), true)
+}
+
+// Diff returns the Option fields which differ between the structs a and b,
+// in the order they are declared. Nested structs and pointers to structs are
+// compared field by field, with a nil pointer being treated like one to a
+// struct whose options are all empty. Present values are compared with
+// reflect.DeepEqual, unless their type holds Options itself, in which case
+// they're compared field by field too.
+//
+// Diff panics if a and b don't have the same type, or are not structs or
+// pointers to structs. Neither may contain pointer cycles.
+func Diff(a, b any) []Change {
+	av, bv := reflect.ValueOf(a), reflect.ValueOf(b)
+	if av.Type() != bv.Type() {
+		panic(fmt.Sprintf("goption: Diff between %T and %T", a, b))
+	}
+	if t := av.Type(); t.Kind() != reflect.Struct && (t.Kind() != reflect.Pointer || t.Elem().Kind() != reflect.Struct) {
+		panic(fmt.Sprintf("goption: Diff of %T, want a struct", a))
+	}
+
+	var changes []Change
+	diffValue(av, bv, "", &changes)
+	return changes
+}
+
+// diffValue appends the changes between the options in a and b, which have
+// the same type, to changes.
+func diffValue(a, b reflect.Value, name string, changes *[]Change) {
+	switch a.Kind() {
+	case reflect.Pointer:
+		if (a.IsNil() && b.IsNil()) || !holdsOptions(a.Type()) {
+			return
+		}
+		diffValue(derefOrZero(a), derefOrZero(b), name, changes)
+
+	case reflect.Struct:
+		if from, isOption := asOption(a); isOption {
+			diffOption(from, b.Interface().(AnyOption), name, changes)
+			return
+		}
+
+		for i := 0; i < a.NumField(); i++ {
+			field := a.Type().Field(i)
+			if !field.IsExported() && !field.Anonymous {
+				continue
+			}
+
+			// Options held through pointers are named like other options.
+			nested := name + field.Name
+			elem := field.Type
+			for elem.Kind() == reflect.Pointer {
+				elem = elem.Elem()
+			}
+			if !IsOptionType(elem) {
+				nested += "."
+				if field.Anonymous {
+					nested = name
+				}
+			}
+			diffValue(a.Field(i), b.Field(i), nested, changes)
+		}
+	}
+}
+
+// diffOption appends the change between the options from and to, if any.
+func diffOption(from, to AnyOption, name string, changes *[]Change) {
+	fromValue, fromOk := from.OptionAny()
+	toValue, toOk := to.OptionAny()
+	switch {
+	case fromOk != toOk:
+		*changes = append(*changes, Change{Field: name, From: from, To: to})
+	case !fromOk:
+	case holdsOptions(from.ElemType()):
+		elem := from.ElemType()
+		diffValue(typedValue(fromValue, elem), typedValue(toValue, elem), name+".", changes)
+	case !reflect.DeepEqual(fromValue, toValue):
+		*changes = append(*changes, Change{Field: name, From: from, To: to})
+	}
+}
+
+// asOption returns v as an AnyOption if it is one.
+func asOption(v reflect.Value) (AnyOption, bool) {
+	if v.Kind() != reflect.Struct || !v.CanInterface() {
+		return nil, false
+	}
+
+	o, isOption := v.Interface().(AnyOption)
+	return o, isOption
+}
+
+// typedValue returns v as a value of type t, which v is assignable to.
+func typedValue(v any, t reflect.Type) reflect.Value {
+	typed := reflect.New(t).Elem()
+	if v != nil {
+		typed.Set(reflect.ValueOf(v))
+	}
+
+	return typed
+}
+
+// derefOrZero returns the value p points to, or the zero value if p is nil.
+func derefOrZero(p reflect.Value) reflect.Value {
+	if p.IsNil() {
+		return reflect.Zero(p.Type().Elem())
+	}
+
+	return p.Elem()
+}
+
+// holdsOptions returns true if t is an Option, or a struct or pointer to a
+// struct with an Option field, directly or nested.
+func holdsOptions(t reflect.Type) bool {
+	return holdsOptionsSeen(t, map[reflect.Type]bool{})
+}
+
+func holdsOptionsSeen(t reflect.Type, seen map[reflect.Type]bool) bool {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || seen[t] {
+		return false
+	}
+	seen[t] = true
+
+	if IsOptionType(t) {
+		return true
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if field := t.Field(i); (field.IsExported() || field.Anonymous) && holdsOptionsSeen(field.Type, seen) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,293 @@
+package goption
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type mergeTLS struct {
+	Cert Option[string]
+	Key  Option[string]
+}
+
+type mergeDB struct {
+	Host Option[string]
+	Port Option[int]
+	TLS  Option[mergeTLS]
+}
+
+type mergeLimits struct {
+	Rate Option[float64]
+}
+
+type mergeConfig struct {
+	mergeLimits
+	Name    Option[string]
+	Tags    Option[[]string]
+	DB      mergeDB
+	Cache   *mergeDB
+	Version string
+	When    Option[struct{ Day, Month int }]
+	private Option[int]
+}
+
+func TestOr(t *testing.T) {
+	if o := Some(1).Or(Some(2)); o != Some(1) {
+		t.Errorf("Expected Some(1), got %v", o)
+	}
+	if o := None[int]().Or(Some(2)); o != Some(2) {
+		t.Errorf("Expected Some(2), got %v", o)
+	}
+	if o := None[int]().Or(None[int]()); o.Ok() {
+		t.Errorf("Expected none, got %v", o)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	defaults := mergeConfig{
+		Name: Some("app"),
+		DB:   mergeDB{Host: Some("localhost"), Port: Some(5432)},
+	}
+	file := &mergeConfig{
+		mergeLimits: mergeLimits{Rate: Some(1.5)},
+		Tags:        Some([]string{"a"}),
+		DB:          mergeDB{Port: Some(6543), TLS: Some(mergeTLS{Cert: Some("cert.pem")})},
+		Cache:       &mergeDB{Host: Some("cache")},
+		Version:     "ignored",
+		private:     Some(1),
+	}
+	env := mergeConfig{
+		Name: Some("prod"),
+		DB:   mergeDB{TLS: Some(mergeTLS{Key: Some("key.pem")})},
+	}
+
+	var cfg mergeConfig
+	Merge(&cfg, defaults, file, (*mergeConfig)(nil), env)
+
+	expected := mergeConfig{
+		mergeLimits: mergeLimits{Rate: Some(1.5)},
+		Name:        Some("prod"),
+		Tags:        Some([]string{"a"}),
+		DB: mergeDB{
+			Host: Some("localhost"),
+			Port: Some(6543),
+			TLS:  Some(mergeTLS{Cert: Some("cert.pem"), Key: Some("key.pem")}),
+		},
+		Cache: &mergeDB{Host: Some("cache")},
+	}
+	if !reflect.DeepEqual(cfg, expected) {
+		t.Errorf("Unexpected merged config:\n%+v\nwant\n%+v", cfg, expected)
+	}
+
+	if cfg.Cache == file.Cache {
+		t.Errorf("Expected pointers to be copied, not shared")
+	}
+	if file.DB.TLS.Unwrap().Key.Ok() {
+		t.Errorf("Expected the sources to be left alone")
+	}
+}
+
+func TestMergeKeepsDst(t *testing.T) {
+	cfg := mergeConfig{Name: Some("dst"), Version: "v1"}
+	Merge(&cfg, mergeConfig{DB: mergeDB{Port: Some(1)}})
+	if cfg.Name != Some("dst") || cfg.Version != "v1" || cfg.DB.Port != Some(1) {
+		t.Errorf("Unexpected merged config: %+v", cfg)
+	}
+}
+
+func TestMergeNonOptionValue(t *testing.T) {
+	// Values without options are replaced rather than merged.
+	cfg := mergeConfig{When: Some(struct{ Day, Month int }{1, 2})}
+	Merge(&cfg, mergeConfig{When: Some(struct{ Day, Month int }{3, 0})})
+	if cfg.When.Unwrap().Day != 3 || cfg.When.Unwrap().Month != 0 {
+		t.Errorf("Unexpected merged value: %v", cfg.When)
+	}
+}
+
+func TestMergeOptionOfPointer(t *testing.T) {
+	type config struct {
+		DB Option[*mergeDB]
+	}
+
+	first := config{DB: Some(&mergeDB{Host: Some("a")})}
+	second := config{DB: Some(&mergeDB{Port: Some(1)})}
+	var cfg config
+	Merge(&cfg, first, second)
+
+	if *cfg.DB.Unwrap() != (mergeDB{Host: Some("a"), Port: Some(1)}) {
+		t.Errorf("Unexpected merged value: %+v", cfg.DB.Unwrap())
+	}
+	if first.DB.Unwrap().Port.Ok() {
+		t.Errorf("Expected the first source to be left alone")
+	}
+}
+
+func TestMergeLeavesSources(t *testing.T) {
+	type outer struct {
+		DB *mergeDB
+	}
+	type config struct {
+		Outer Option[outer]
+		Cache *mergeDB
+	}
+
+	first := config{
+		Outer: Some(outer{DB: &mergeDB{Host: Some("a"), TLS: Some(mergeTLS{Cert: Some("cert")})}}),
+		Cache: &mergeDB{Host: Some("cache")},
+	}
+	second := config{
+		Outer: Some(outer{DB: &mergeDB{Host: Some("b"), Port: Some(1), TLS: Some(mergeTLS{Key: Some("key")})}}),
+		Cache: &mergeDB{Port: Some(2)},
+	}
+
+	var cfg config
+	Merge(&cfg, first, second)
+
+	db := mergeDB{Host: Some("b"), Port: Some(1), TLS: Some(mergeTLS{Cert: Some("cert"), Key: Some("key")})}
+	if *cfg.Outer.Unwrap().DB != db {
+		t.Errorf("Unexpected merged value: %+v", *cfg.Outer.Unwrap().DB)
+	}
+	if *first.Outer.Unwrap().DB != (mergeDB{Host: Some("a"), TLS: Some(mergeTLS{Cert: Some("cert")})}) {
+		t.Errorf("Expected the first source to be left alone, got %+v", *first.Outer.Unwrap().DB)
+	}
+
+	// A dst copied from a source shares its pointers.
+	cfg = first
+	Merge(&cfg, second)
+	if *cfg.Outer.Unwrap().DB != db || *cfg.Cache != (mergeDB{Host: Some("cache"), Port: Some(2)}) {
+		t.Errorf("Unexpected merged config: %+v", cfg)
+	}
+	if first.Outer.Unwrap().DB.Port.Ok() || first.Cache.Port.Ok() {
+		t.Errorf("Expected the source dst was copied from to be left alone")
+	}
+}
+
+func TestMergePanics(t *testing.T) {
+	for want, f := range map[string]func(){
+		"goption: Merge into goption.mergeConfig, want a pointer to a struct": func() {
+			Merge(mergeConfig{})
+		},
+		"goption: Merge from goption.mergeDB into *goption.mergeConfig": func() {
+			Merge(&mergeConfig{}, mergeDB{})
+		},
+	} {
+		func() {
+			defer func() {
+				if r := recover(); fmt.Sprint(r) != want {
+					t.Errorf("Expected panic %q, got %v", want, r)
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+func TestDiff(t *testing.T) {
+	a := mergeConfig{
+		mergeLimits: mergeLimits{Rate: Some(1.0)},
+		Name:        Some("app"),
+		Tags:        Some([]string{"a"}),
+		DB:          mergeDB{Host: Some("localhost"), TLS: Some(mergeTLS{Cert: Some("a.pem")})},
+		Version:     "1",
+	}
+	b := a
+	b.Name = None[string]()
+	b.Tags = Some([]string{"a"})
+	b.DB.Port = Some(5432)
+	b.DB.TLS = Some(mergeTLS{Cert: Some("b.pem")})
+	b.Cache = &mergeDB{}
+	b.Version = "2"
+
+	if changes := Diff(a, a); len(changes) != 0 {
+		t.Errorf("Expected no changes, got %v", changes)
+	}
+
+	changes := Diff(&a, &b)
+	var descriptions []string
+	for _, c := range changes {
+		descriptions = append(descriptions, c.String())
+	}
+	expected := []string{
+		"Name: app -> null",
+		"DB.Port: null -> 5432",
+		"DB.TLS.Cert: a.pem -> b.pem",
+	}
+	if strings.Join(descriptions, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Unexpected changes:\n%s\nwant\n%s", strings.Join(descriptions, "\n"), strings.Join(expected, "\n"))
+	}
+
+	if !changes[0].Removed() || changes[0].Added() {
+		t.Errorf("Expected Name to be removed")
+	}
+	if !changes[1].Added() || changes[1].Removed() {
+		t.Errorf("Expected DB.Port to be added")
+	}
+	if changes[2].Added() || changes[2].Removed() {
+		t.Errorf("Expected DB.TLS.Cert to be modified")
+	}
+	if changes[1].To.(Option[int]) != Some(5432) {
+		t.Errorf("Unexpected new value: %v", changes[1].To)
+	}
+}
+
+func TestDiffNilPointer(t *testing.T) {
+	a := mergeConfig{}
+	b := mergeConfig{Cache: &mergeDB{Port: Some(1)}}
+	changes := Diff(a, b)
+	if len(changes) != 1 || changes[0].Field != "Cache.Port" {
+		t.Errorf("Unexpected changes: %v", changes)
+	}
+
+	changes = Diff(b, a)
+	if len(changes) != 1 || !changes[0].Removed() {
+		t.Errorf("Unexpected changes: %v", changes)
+	}
+}
+
+func TestDiffOptionPointer(t *testing.T) {
+	type config struct {
+		P *Option[int]
+	}
+
+	one := Some(1)
+	two := Some(2)
+	for _, tt := range []struct {
+		a, b config
+		want string
+	}{
+		{config{}, config{P: &one}, "P: null -> 1"},
+		{config{P: &one}, config{P: &two}, "P: 1 -> 2"},
+		{config{P: &two}, config{}, "P: 2 -> null"},
+	} {
+		changes := Diff(tt.a, tt.b)
+		if len(changes) != 1 || changes[0].String() != tt.want {
+			t.Errorf("Expected %q, got %v", tt.want, changes)
+		}
+	}
+
+	if changes := Diff(config{P: &one}, config{P: &one}); len(changes) != 0 {
+		t.Errorf("Expected no changes, got %v", changes)
+	}
+}
+
+func TestDiffPanics(t *testing.T) {
+	for want, f := range map[string]func(){
+		"goption: Diff between goption.mergeConfig and goption.mergeDB": func() {
+			Diff(mergeConfig{}, mergeDB{})
+		},
+		"goption: Diff of int, want a struct": func() {
+			Diff(1, 2)
+		},
+	} {
+		func() {
+			defer func() {
+				if r := recover(); fmt.Sprint(r) != want {
+					t.Errorf("Expected panic %q, got %v", want, r)
+				}
+			}()
+			f()
+		}()
+	}
+}
@@ -0,0 +1,53 @@
+// Package msgpackoption adapts goption.Option for use with
+// github.com/vmihailenco/msgpack/v5.
+//
+// Empty options are encoded as nil, exactly like a nil pointer, so a *T field
+// can be replaced with an Option[T] without changing the encoded bytes.
+package msgpackoption
+
+import (
+	"github.com/jordan-bonecutter/goption"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// Option wraps goption.Option so that it implements msgpack.CustomEncoder and
+// msgpack.CustomDecoder. Every method of goption.Option is available on it.
+type Option[T any] struct {
+	goption.Option[T]
+}
+
+// Wrap returns o as a msgpack compatible Option.
+func Wrap[T any](o goption.Option[T]) Option[T] {
+	return Option[T]{o}
+}
+
+// Some returns an Option whose underlying value is present.
+func Some[T any](t T) Option[T] {
+	return Wrap(goption.Some(t))
+}
+
+// None returns an empty optional value.
+func None[T any]() Option[T] {
+	return Wrap(goption.None[T]())
+}
+
+// EncodeMsgpack implements msgpack.CustomEncoder.
+func (o Option[T]) EncodeMsgpack(enc *msgpack.Encoder) error {
+	t, ok := o.Get()
+	if !ok {
+		return enc.EncodeNil()
+	}
+
+	return enc.Encode(t)
+}
+
+// DecodeMsgpack implements msgpack.CustomDecoder.
+func (o *Option[T]) DecodeMsgpack(dec *msgpack.Decoder) error {
+	var t *T
+	if err := dec.Decode(&t); err != nil {
+		return err
+	}
+
+	*o = Wrap(goption.FromRef(t))
+	return nil
+}
@@ -0,0 +1,40 @@
+package goption
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// NonNull is an Option which may be absent from JSON but must not be null.
+// Unmarshalling an explicit null returns a *json.UnmarshalTypeError whose
+// Field holds the path of the offending value.
+// It marshals like Option, so None is still encoded as null.
+type NonNull[T any] struct {
+	Option[T]
+}
+
+// NonNullOption is implemented by every NonNull, and by types which embed
+// one, so that reflection based code can tell them apart from other options.
+type NonNullOption interface {
+	AnyOption
+	nonNull()
+}
+
+func (NonNull[T]) nonNull() {}
+
+// UnmarshalJSON unmarshals the underlying option data, rejecting null.
+func (n *NonNull[T]) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nullError[T]()
+	}
+
+	return n.Option.UnmarshalJSON(data)
+}
+
+// nullError returns the error for a null where a T was required.
+func nullError[T any]() *json.UnmarshalTypeError {
+	return &json.UnmarshalTypeError{
+		Value: "null",
+		Type:  reflect.TypeFor[T](),
+	}
+}
@@ -0,0 +1,69 @@
+package goption
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+type nonNullFoo struct {
+	Inner struct {
+		Count NonNull[int] `json:"count"`
+	} `json:"inner"`
+}
+
+func TestNonNullUnmarshal(t *testing.T) {
+	var foo nonNullFoo
+	if err := json.Unmarshal([]byte(`{"inner":{}}`), &foo); err != nil {
+		t.Errorf("Failed unmarshalling into foo: %s", err)
+	} else if foo.Inner.Count.Ok() {
+		t.Errorf("Expected optional value to be empty.")
+	}
+
+	if err := json.Unmarshal([]byte(`{"inner":{"count":0}}`), &foo); err != nil {
+		t.Errorf("Failed unmarshalling into foo: %s", err)
+	} else if !foo.Inner.Count.Ok() || foo.Inner.Count.Unwrap() != 0 {
+		t.Errorf("Expected optional value to be present.")
+	}
+}
+
+func TestNonNullUnmarshalNull(t *testing.T) {
+	var foo nonNullFoo
+	err := json.Unmarshal([]byte(`{"inner":{"count":null}}`), &foo)
+
+	var typeErr *json.UnmarshalTypeError
+	if !errors.As(err, &typeErr) {
+		t.Fatalf("Expected a type error unmarshalling null, got %v", err)
+	}
+
+	if typeErr.Value != "null" || typeErr.Type.String() != "int" {
+		t.Errorf("Unexpected type error: %s", typeErr)
+	}
+
+	if !strings.HasSuffix(typeErr.Field, "inner.count") || !strings.Contains(err.Error(), "inner.count") {
+		t.Errorf("Expected the error to include the path: %s", err)
+	}
+}
+
+func TestNonNullMarshal(t *testing.T) {
+	encoded, err := json.Marshal(nonNullFoo{})
+	if err != nil {
+		t.Fatalf("Failed marshalling json: %s", err)
+	}
+
+	if string(encoded) != `{"inner":{"count":null}}` {
+		t.Errorf("Unexpected encoded data: %s", string(encoded))
+	}
+
+	var foo nonNullFoo
+	foo.Inner.Count = NonNull[int]{Some(1)}
+	encoded, err = json.Marshal(foo)
+	if err != nil {
+		t.Fatalf("Failed marshalling json: %s", err)
+	}
+
+	if string(encoded) != `{"inner":{"count":1}}` {
+		t.Errorf("Unexpected encoded data: %s", string(encoded))
+	}
+}
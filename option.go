@@ -1,6 +1,9 @@
 package goption
 
-import "iter"
+import (
+	"fmt"
+	"iter"
+)
 
 // Option represents a value whose presence is optional.
 type Option[T any] struct {
@@ -78,6 +81,44 @@ func (o Option[T]) Get() (T, bool) {
 	return o.t, o.ok
 }
 
+// Or returns o if it's present, otherwise it returns other.
+func (o Option[T]) Or(other Option[T]) Option[T] {
+	if !o.ok {
+		return other
+	}
+
+	return o
+}
+
+// OptionAny returns the underlying value as an any and a boolean indicating
+// if it's present. It lets reflection based code read options without
+// knowing T.
+func (o Option[T]) OptionAny() (any, bool) {
+	return o.t, o.ok
+}
+
+// SetOptionAny sets o to Some(v) if ok is true, and to None otherwise.
+// It lets reflection based code write options without knowing T.
+// A nil v sets the zero value of T, and SetOptionAny panics if v is not a T.
+func (o *Option[T]) SetOptionAny(v any, ok bool) {
+	if !ok {
+		*o = None[T]()
+		return
+	}
+
+	if v == nil {
+		var zero T
+		*o = Some(zero)
+		return
+	}
+
+	t, isT := v.(T)
+	if !isT {
+		panic(fmt.Sprintf("goption: SetOptionAny with %T on %T", v, *o))
+	}
+	*o = Some(t)
+}
+
 // Some returns an Option whose underlying value is present.
 func Some[T any](t T) Option[T] {
 	return Option[T]{
@@ -102,6 +143,15 @@ func FromRef[T any](t *T) Option[T] {
 	return Some(*t)
 }
 
+// ToRef returns a pointer to a copy of the value if o is present.
+// Otherwise, it returns nil.
+func (o Option[T]) ToRef() *T {
+	if !o.ok {
+		return nil
+	}
+	return &o.t
+}
+
 // Apply f to the optional value.
 func Apply[In, Out any](in Option[In], f func(In) Out) Option[Out] {
 	if !in.ok {
@@ -143,13 +193,17 @@ func (o *Option[T]) With() iter.Seq[T] {
 	}
 }
 
-// IsZero returns true if o is not present.
-// This is for use with encoding/json in go1.24+.
-func (o *Option[T]) IsZero() bool {
-	if o.ok {
-		if isZeroer, wrapsIsZeroer := (any(o.t)).(interface{ IsZero() bool }); wrapsIsZeroer {
-			return isZeroer.IsZero()
-		}
+// IsZero returns true if o is not present, or if it wraps a value whose
+// IsZero method returns true.
+// This is for use with omitzero in encoding/json (go1.24+) and with omitempty
+// in gopkg.in/yaml.v3, which requires a value receiver.
+func (o Option[T]) IsZero() bool {
+	if !o.ok {
+		return true
+	}
+
+	if isZeroer, wrapsIsZeroer := (any(o.t)).(interface{ IsZero() bool }); wrapsIsZeroer {
+		return isZeroer.IsZero()
 	}
 	return false
 }
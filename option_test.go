@@ -198,6 +198,23 @@ func TestFromRef(t *testing.T) {
 	}
 }
 
+func TestToRef(t *testing.T) {
+	if ref := None[int]().ToRef(); ref != nil {
+		t.Errorf("ToRef must be nil for none, got %v", ref)
+	}
+
+	opt := Some(10)
+	ref := opt.ToRef()
+	if ref == nil || *ref != 10 {
+		t.Fatalf("ToRef must point to the value, got %v", ref)
+	}
+
+	*ref = 11
+	if opt.Unwrap() != 10 {
+		t.Errorf("ToRef must point to a copy of the value")
+	}
+}
+
 // TestUnwrapRefOr tests that or values are returned when unwrap or-ing none.
 // Otherwise it expects the underlying optional value.
 func TestUnwrapRefOr(t *testing.T) {
@@ -265,8 +282,8 @@ func TestIsZeroSome(t *testing.T) {
 
 func TestIsZeroNone(t *testing.T) {
 	opt := None[int]()
-	if opt.IsZero() {
-		t.Fatalf("Expected to not be zero")
+	if !opt.IsZero() {
+		t.Fatalf("Expected to be zero")
 	}
 }
 
@@ -289,3 +306,41 @@ func TestIsZeroWrappedNotZero(t *testing.T) {
 		t.Fatalf("Expected not to be zero")
 	}
 }
+
+func TestOptionAny(t *testing.T) {
+	if v, ok := Some(3).OptionAny(); !ok || v != 3 {
+		t.Errorf("Unexpected OptionAny for some: %v, %v", v, ok)
+	}
+	if v, ok := None[string]().OptionAny(); ok || v != "" {
+		t.Errorf("Unexpected OptionAny for none: %v, %v", v, ok)
+	}
+}
+
+func TestSetOptionAny(t *testing.T) {
+	var opt Option[int]
+	opt.SetOptionAny(3, true)
+	if opt != Some(3) {
+		t.Errorf("Expected Some(3), got %v", opt)
+	}
+
+	opt.SetOptionAny(4, false)
+	if opt.Ok() {
+		t.Errorf("Expected none, got %v", opt)
+	}
+
+	var ptr Option[*int]
+	ptr.SetOptionAny(nil, true)
+	if !ptr.Ok() || ptr.Unwrap() != nil {
+		t.Errorf("Expected Some(nil), got %v", ptr)
+	}
+}
+
+func TestSetOptionAnyWrongType(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected to fail setting a string in an int option")
+		}
+	}()
+	var opt Option[int]
+	opt.SetOptionAny("3", true)
+}
@@ -0,0 +1,35 @@
+package optiontest
+
+import (
+	"reflect"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jordan-bonecutter/goption"
+)
+
+// optionValue is what EquateOptions compares in place of an Option.
+type optionValue struct {
+	Ok    bool
+	Value any
+}
+
+// EquateOptions returns a cmp.Option which compares goption.Options by
+// presence and value, without looking at their unexported fields.
+// Wrapper types which embed goption.Option are compared through the
+// embedded field.
+func EquateOptions() cmp.Option {
+	return cmp.FilterPath(func(p cmp.Path) bool {
+		return isOption(p.Last().Type())
+	}, cmp.Transformer("goption.Option", func(o any) optionValue {
+		value, ok := o.(goption.AnyOption).OptionAny()
+		if !ok {
+			return optionValue{}
+		}
+		return optionValue{Ok: true, Value: value}
+	}))
+}
+
+// isOption returns true if t is a goption.Option, or a struct embedding one.
+func isOption(t reflect.Type) bool {
+	return goption.IsOptionType(t)
+}
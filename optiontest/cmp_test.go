@@ -0,0 +1,60 @@
+package optiontest
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jordan-bonecutter/goption"
+	"github.com/jordan-bonecutter/goption/tomloption"
+)
+
+type record struct {
+	Name   string
+	Count  goption.Option[int]
+	Tags   goption.Option[[]string]
+	Nested goption.Option[goption.Option[int]]
+	Port   tomloption.Option[int]
+	Strict goption.NonNull[int]
+}
+
+func TestEquateOptions(t *testing.T) {
+	a := record{
+		Name:   "a",
+		Count:  goption.Some(1),
+		Tags:   goption.Some([]string{"x"}),
+		Nested: goption.Some(goption.None[int]()),
+		Port:   tomloption.Some(80),
+		Strict: goption.NonNull[int]{Option: goption.Some(1)},
+	}
+	b := a
+	b.Tags = goption.Some([]string{"x"})
+
+	if diff := cmp.Diff(a, b, EquateOptions()); diff != "" {
+		t.Errorf("Expected records to be equal:\n%s", diff)
+	}
+
+	for _, change := range []func(*record){
+		func(r *record) { r.Count = goption.None[int]() },
+		func(r *record) { r.Count = goption.Some(2) },
+		func(r *record) { r.Tags = goption.Some([]string{"y"}) },
+		func(r *record) { r.Nested = goption.Some(goption.Some(0)) },
+		func(r *record) { r.Port = tomloption.None[int]() },
+		func(r *record) { r.Strict = goption.NonNull[int]{Option: goption.Some(2)} },
+	} {
+		c := a
+		change(&c)
+		if cmp.Equal(a, c, EquateOptions()) {
+			t.Errorf("Expected %#v to differ from %#v", c, a)
+		}
+	}
+}
+
+func TestEquateOptionsWithoutOption(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected cmp to panic on unexported fields without EquateOptions")
+		}
+	}()
+
+	cmp.Equal(goption.Some(1), goption.Some(1))
+}
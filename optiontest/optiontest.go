@@ -0,0 +1,63 @@
+// Package optiontest provides assertions, testing/quick generators and a
+// go-cmp option for testing code which uses goption.Option.
+package optiontest
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jordan-bonecutter/goption"
+)
+
+// AssertSome reports an error if opt isn't Some(want).
+// Values are compared with reflect.DeepEqual.
+// It returns true if the assertion passed.
+func AssertSome[T any](t testing.TB, opt goption.Option[T], want T) bool {
+	t.Helper()
+	if got, ok := opt.Get(); !ok || !reflect.DeepEqual(got, want) {
+		t.Errorf("got %s, want %s", describe(opt), describe(goption.Some(want)))
+		return false
+	}
+
+	return true
+}
+
+// AssertNone reports an error if opt is present.
+// It returns true if the assertion passed.
+func AssertNone[T any](t testing.TB, opt goption.Option[T]) bool {
+	t.Helper()
+	if opt.Ok() {
+		t.Errorf("got %s, want %s", describe(opt), describe(goption.None[T]()))
+		return false
+	}
+
+	return true
+}
+
+// AssertSomeFunc reports an error if opt is empty or pred returns false for
+// its value.
+// It returns true if the assertion passed.
+func AssertSomeFunc[T any](t testing.TB, opt goption.Option[T], pred func(T) bool) bool {
+	t.Helper()
+	got, ok := opt.Get()
+	if !ok {
+		t.Errorf("got %s, want Some", describe(opt))
+		return false
+	}
+
+	if !pred(got) {
+		t.Errorf("got %s, which doesn't satisfy the predicate", describe(opt))
+		return false
+	}
+
+	return true
+}
+
+// describe formats opt with its GoString, marking present values with Some.
+func describe[T any](opt goption.Option[T]) string {
+	if !opt.Ok() {
+		return opt.GoString()
+	}
+
+	return "Some(" + opt.GoString() + ")"
+}
@@ -0,0 +1,80 @@
+package optiontest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/jordan-bonecutter/goption"
+)
+
+// recorder is a testing.TB which records errors instead of failing.
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestAssertSome(t *testing.T) {
+	r := &recorder{TB: t}
+	if !AssertSome(r, goption.Some([]int{1, 2}), []int{1, 2}) || len(r.errors) != 0 {
+		t.Errorf("Expected AssertSome to pass, got %v", r.errors)
+	}
+
+	if AssertSome(r, goption.Some(4), 3) {
+		t.Errorf("Expected AssertSome to fail for a different value")
+	}
+	if AssertSome(r, goption.None[int](), 3) {
+		t.Errorf("Expected AssertSome to fail for none")
+	}
+
+	expected := []string{
+		"got Some(4), want Some(3)",
+		"got Option[int]{ok: false}, want Some(3)",
+	}
+	if fmt.Sprint(r.errors) != fmt.Sprint(expected) {
+		t.Errorf("Unexpected errors: %q", r.errors)
+	}
+}
+
+func TestAssertNone(t *testing.T) {
+	r := &recorder{TB: t}
+	if !AssertNone(r, goption.None[string]()) || len(r.errors) != 0 {
+		t.Errorf("Expected AssertNone to pass, got %v", r.errors)
+	}
+
+	if AssertNone(r, goption.Some("hey")) {
+		t.Errorf("Expected AssertNone to fail for some")
+	}
+	if len(r.errors) != 1 || r.errors[0] != `got Some("hey"), want Option[string]{ok: false}` {
+		t.Errorf("Unexpected errors: %q", r.errors)
+	}
+}
+
+func TestAssertSomeFunc(t *testing.T) {
+	even := func(i int) bool { return i%2 == 0 }
+
+	r := &recorder{TB: t}
+	if !AssertSomeFunc(r, goption.Some(2), even) || len(r.errors) != 0 {
+		t.Errorf("Expected AssertSomeFunc to pass, got %v", r.errors)
+	}
+
+	if AssertSomeFunc(r, goption.Some(3), even) {
+		t.Errorf("Expected AssertSomeFunc to fail when the predicate fails")
+	}
+	if AssertSomeFunc(r, goption.None[int](), even) {
+		t.Errorf("Expected AssertSomeFunc to fail for none")
+	}
+
+	expected := []string{
+		"got Some(3), which doesn't satisfy the predicate",
+		"got Option[int]{ok: false}, want Some",
+	}
+	if fmt.Sprint(r.errors) != fmt.Sprint(expected) {
+		t.Errorf("Unexpected errors: %q", r.errors)
+	}
+}
@@ -0,0 +1,40 @@
+package optiontest
+
+import (
+	"math/rand"
+	"reflect"
+	"testing/quick"
+
+	"github.com/jordan-bonecutter/goption"
+)
+
+// Gen is an Option which testing/quick can generate. It is None a quarter
+// of the time, and otherwise holds a random T.
+//
+//	quick.Check(func(o optiontest.Gen[int]) bool {
+//	  return o.UnwrapOr(0) == o.UnwrapOrDefault()
+//	}, nil)
+type Gen[T any] struct {
+	goption.Option[T]
+}
+
+// Generate implements quick.Generator.
+func (Gen[T]) Generate(rand *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(Gen[T]{Generate[T](rand, size)})
+}
+
+// Generate returns a random Option, which is None a quarter of the time.
+// Values are generated with quick.Value, so it panics if T can't be
+// generated.
+func Generate[T any](rand *rand.Rand, size int) goption.Option[T] {
+	if rand.Intn(4) == 0 {
+		return goption.None[T]()
+	}
+
+	v, ok := quick.Value(reflect.TypeFor[T](), rand)
+	if !ok {
+		panic("optiontest: can't generate values of type " + reflect.TypeFor[T]().String())
+	}
+
+	return goption.Some(v.Interface().(T))
+}
@@ -0,0 +1,56 @@
+package optiontest
+
+import (
+	"math/rand"
+	"testing"
+	"testing/quick"
+
+	"github.com/jordan-bonecutter/goption"
+)
+
+func TestGenerate(t *testing.T) {
+	rand := rand.New(rand.NewSource(1))
+	somes := 0
+	for i := 0; i < 1000; i++ {
+		if Generate[string](rand, 10).Ok() {
+			somes++
+		}
+	}
+
+	if somes < 650 || somes > 850 {
+		t.Errorf("Expected about three quarters of the options to be some, got %d/1000", somes)
+	}
+}
+
+func TestGenerateUnsupported(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected generating a func to panic")
+		}
+	}()
+
+	rand := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		Generate[func()](rand, 10)
+	}
+}
+
+func TestGenQuickCheck(t *testing.T) {
+	sawNone, sawSome := false, false
+	err := quick.Check(func(o Gen[int], p Gen[[]string]) bool {
+		if o.Ok() {
+			sawSome = true
+		} else {
+			sawNone = true
+		}
+
+		return goption.FromRef(o.ToRef()) == o.Option && p.Ok() == (p.ToRef() != nil)
+	}, nil)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if !sawNone || !sawSome {
+		t.Errorf("Expected both none and some to be generated")
+	}
+}
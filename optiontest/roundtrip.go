@@ -0,0 +1,77 @@
+package optiontest
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// Codec is an encoding checked by RoundTrip.
+type Codec struct {
+	Name      string
+	Marshal   func(v any) ([]byte, error)
+	Unmarshal func(data []byte, v any) error
+}
+
+// JSON is the encoding/json Codec.
+var JSON = Codec{
+	Name:      "json",
+	Marshal:   json.Marshal,
+	Unmarshal: json.Unmarshal,
+}
+
+// Text is the encoding.TextMarshaler Codec, for values which implement it.
+var Text = Codec{
+	Name: "text",
+	Marshal: func(v any) ([]byte, error) {
+		marshaler, ok := v.(encoding.TextMarshaler)
+		if !ok {
+			return nil, fmt.Errorf("%T is not an encoding.TextMarshaler", v)
+		}
+		return marshaler.MarshalText()
+	},
+	Unmarshal: func(data []byte, v any) error {
+		unmarshaler, ok := v.(encoding.TextUnmarshaler)
+		if !ok {
+			return fmt.Errorf("%T is not an encoding.TextUnmarshaler", v)
+		}
+		return unmarshaler.UnmarshalText(data)
+	},
+}
+
+// RoundTrip reports an error for each value which doesn't decode back to
+// itself after being encoded with c. Values are compared with go-cmp and
+// EquateOptions, so T may contain options anywhere, but any other
+// unexported fields need extra opts.
+// It returns true if every value passed.
+func RoundTrip[T any](t testing.TB, c Codec, values []T, opts ...cmp.Option) bool {
+	t.Helper()
+	opts = append([]cmp.Option{EquateOptions()}, opts...)
+
+	passed := true
+	for _, v := range values {
+		data, err := c.Marshal(v)
+		if err != nil {
+			t.Errorf("%s: failed marshalling %#v: %s", c.Name, v, err)
+			passed = false
+			continue
+		}
+
+		var decoded T
+		if err := c.Unmarshal(data, &decoded); err != nil {
+			t.Errorf("%s: failed unmarshalling %q: %s", c.Name, data, err)
+			passed = false
+			continue
+		}
+
+		if diff := cmp.Diff(v, decoded, opts...); diff != "" {
+			t.Errorf("%s: %q didn't round trip (-want +got):\n%s", c.Name, data, diff)
+			passed = false
+		}
+	}
+
+	return passed
+}
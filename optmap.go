@@ -0,0 +1,90 @@
+package goption
+
+import (
+	"iter"
+	"maps"
+)
+
+// OptMap is a map whose lookups return options.
+// Methods which insert allocate the map if it is nil.
+type OptMap[K comparable, V any] map[K]V
+
+// Get returns the value for k, or none if k is not in m.
+func (m OptMap[K, V]) Get(k K) Option[V] {
+	return Lookup(m, k)
+}
+
+// Insert sets the value for k to v, returning the previous value if any.
+func (m *OptMap[K, V]) Insert(k K, v V) Option[V] {
+	if *m == nil {
+		*m = OptMap[K, V]{}
+	}
+
+	prev := m.Get(k)
+	(*m)[k] = v
+	return prev
+}
+
+// Remove deletes k from m, returning its value if it was present.
+func (m OptMap[K, V]) Remove(k K) Option[V] {
+	prev := m.Get(k)
+	delete(m, k)
+	return prev
+}
+
+// All yields every key and value in m, in no particular order.
+func (m OptMap[K, V]) All() iter.Seq2[K, V] {
+	return maps.All(m)
+}
+
+// Entry returns the entry for k in m, for inspecting or updating it in place.
+func (m *OptMap[K, V]) Entry(k K) Entry[K, V] {
+	return Entry[K, V]{m: m, key: k}
+}
+
+// Entry is a key of an OptMap, which may or may not be present.
+type Entry[K comparable, V any] struct {
+	m   *OptMap[K, V]
+	key K
+}
+
+// Key returns the key of e.
+func (e Entry[K, V]) Key() K {
+	return e.key
+}
+
+// Get returns the value of e, or none if it's not in the map.
+func (e Entry[K, V]) Get() Option[V] {
+	return e.m.Get(e.key)
+}
+
+// OrInsert inserts v if e is not in the map, and returns the value of e.
+func (e Entry[K, V]) OrInsert(v V) V {
+	return e.OrInsertWith(func() V {
+		return v
+	})
+}
+
+// OrInsertWith inserts the result of f if e is not in the map, and returns
+// the value of e. f is only called if the value is inserted.
+func (e Entry[K, V]) OrInsertWith(f func() V) V {
+	if v, ok := e.Get().Get(); ok {
+		return v
+	}
+
+	v := f()
+	e.m.Insert(e.key, v)
+	return v
+}
+
+// AndModify calls f with a pointer to the value of e if it's in the map, and
+// stores the modified value. It returns e for chaining, such as with
+// m.Entry(k).AndModify(increment).OrInsert(1).
+func (e Entry[K, V]) AndModify(f func(*V)) Entry[K, V] {
+	if v, ok := e.Get().Get(); ok {
+		f(&v)
+		(*e.m)[e.key] = v
+	}
+
+	return e
+}
@@ -0,0 +1,78 @@
+package goption
+
+import (
+	"maps"
+	"testing"
+)
+
+func TestOptMap(t *testing.T) {
+	var m OptMap[string, int]
+	if o := m.Get("a"); o.Ok() {
+		t.Errorf("Expected none from a nil map, got %v", o)
+	}
+	if o := m.Remove("a"); o.Ok() {
+		t.Errorf("Expected none removing from a nil map, got %v", o)
+	}
+
+	if prev := m.Insert("a", 1); prev.Ok() {
+		t.Errorf("Expected no previous value, got %v", prev)
+	}
+	if prev := m.Insert("a", 2); prev != Some(1) {
+		t.Errorf("Expected previous value 1, got %v", prev)
+	}
+	if o := m.Get("a"); o != Some(2) {
+		t.Errorf("Expected 2, got %v", o)
+	}
+
+	m.Insert("b", 3)
+	if len(m) != 2 || !maps.Equal(maps.Collect(m.All()), map[string]int{"a": 2, "b": 3}) {
+		t.Errorf("Unexpected map: %v", m)
+	}
+
+	if o := m.Remove("a"); o != Some(2) {
+		t.Errorf("Expected to remove 2, got %v", o)
+	}
+	if _, found := m["a"]; found {
+		t.Errorf("Expected a to be removed")
+	}
+}
+
+func TestOptMapWraps(t *testing.T) {
+	m := map[string]int{"a": 1}
+	opt := OptMap[string, int](m)
+	opt.Insert("b", 2)
+	if m["b"] != 2 {
+		t.Errorf("Expected inserts to modify the wrapped map")
+	}
+}
+
+func TestEntry(t *testing.T) {
+	var counts OptMap[string, int]
+	increment := func(n *int) { *n++ }
+	for _, word := range []string{"a", "b", "a", "a"} {
+		counts.Entry(word).AndModify(increment).OrInsert(1)
+	}
+	if !maps.Equal(counts, OptMap[string, int]{"a": 3, "b": 1}) {
+		t.Errorf("Unexpected counts: %v", counts)
+	}
+
+	e := counts.Entry("c")
+	if e.Key() != "c" || e.Get().Ok() {
+		t.Errorf("Expected an empty entry for c")
+	}
+
+	calls := 0
+	f := func() int {
+		calls++
+		return 5
+	}
+	if v := e.OrInsertWith(f); v != 5 || counts["c"] != 5 {
+		t.Errorf("Expected 5 to be inserted, got %v", v)
+	}
+	if v := e.OrInsertWith(f); v != 5 || calls != 1 {
+		t.Errorf("Expected f to be called once, got %d calls", calls)
+	}
+	if v := counts.Entry("a").OrInsert(10); v != 3 {
+		t.Errorf("Expected the existing value 3, got %v", v)
+	}
+}
@@ -0,0 +1,176 @@
+package goption
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// OptRef is an optional value held by pointer. Unlike Option[*T] it has a
+// single empty state: it is present exactly when its pointer isn't nil. Copies
+// of an OptRef share the value, so changes made through Ptr are seen by all of
+// them and large values aren't copied.
+// The zero value is empty.
+type OptRef[T any] struct {
+	p *T
+}
+
+// FromPtr returns an OptRef holding p, which is empty if p is nil.
+// Unlike FromRef, the value is not copied.
+func FromPtr[T any](p *T) OptRef[T] {
+	return OptRef[T]{p: p}
+}
+
+// ToOptRef returns an OptRef holding a copy of the value of o, or an empty
+// OptRef if o is empty.
+func ToOptRef[T any](o Option[T]) OptRef[T] {
+	return FromPtr(o.ToRef())
+}
+
+// Ok returns if the value is present.
+func (r OptRef[T]) Ok() bool {
+	return r.p != nil
+}
+
+// Ptr returns the pointer to the value, or nil if it's not present.
+func (r OptRef[T]) Ptr() *T {
+	return r.p
+}
+
+// Get returns the pointer to the value and a boolean indicating if it's present.
+func (r OptRef[T]) Get() (*T, bool) {
+	return r.p, r.p != nil
+}
+
+// Unwrap returns the pointer to the value, panicking if it's not present.
+func (r OptRef[T]) Unwrap() *T {
+	if r.p == nil {
+		panic("Unwrapped empty optional")
+	}
+
+	return r.p
+}
+
+// Deref returns an Option holding a copy of the value, or none if it's not
+// present.
+func (r OptRef[T]) Deref() Option[T] {
+	return FromRef(r.p)
+}
+
+// OptionAny implements AnyOption, returning a copy of the value as an any.
+func (r OptRef[T]) OptionAny() (any, bool) {
+	if r.p == nil {
+		var zero T
+		return zero, false
+	}
+
+	return *r.p, true
+}
+
+// ElemType implements AnyOption, returning T.
+func (r OptRef[T]) ElemType() reflect.Type {
+	return reflect.TypeFor[T]()
+}
+
+// SetOptionAny is like Option.SetOptionAny, with r holding a newly allocated
+// copy of v so that copies of r are left alone.
+func (r *OptRef[T]) SetOptionAny(v any, ok bool) {
+	if !ok {
+		r.p = nil
+		return
+	}
+
+	p := new(T)
+	if v != nil {
+		t, isT := v.(T)
+		if !isT {
+			panic(fmt.Sprintf("goption: SetOptionAny with %T on %T", v, *r))
+		}
+		*p = t
+	}
+	r.p = p
+}
+
+// String implements fmt.Stringer like Option.
+func (r OptRef[T]) String() string {
+	return r.Deref().String()
+}
+
+// IsZero returns true if r is not present, or if it holds a value whose
+// IsZero method returns true, like Option.IsZero.
+func (r OptRef[T]) IsZero() bool {
+	if r.p == nil {
+		return true
+	}
+
+	if isZeroer, isIsZeroer := (any(r.p)).(interface{ IsZero() bool }); isIsZeroer {
+		return isZeroer.IsZero()
+	}
+	return false
+}
+
+// MarshalJSON marshals the value like Option, without copying it.
+func (r OptRef[T]) MarshalJSON() ([]byte, error) {
+	if r.p == nil {
+		return []byte("null"), nil
+	}
+
+	if data, ok := marshalJSON(r.p); ok {
+		return data, nil
+	}
+
+	return json.Marshal(r.p)
+}
+
+// UnmarshalJSON unmarshals into a newly allocated value like Option, so that
+// copies of r are left alone.
+func (r *OptRef[T]) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		r.p = nil
+		return nil
+	}
+
+	p := new(T)
+	if !parseJSON(data, p) {
+		if err := json.Unmarshal(data, p); err != nil {
+			return err
+		}
+	}
+
+	r.p = p
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler like Option.
+func (r OptRef[T]) MarshalText() ([]byte, error) {
+	return r.Deref().MarshalText()
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler like Option, unmarshalling
+// into a newly allocated value.
+func (r *OptRef[T]) UnmarshalText(data []byte) error {
+	var o Option[T]
+	if err := o.UnmarshalText(data); err != nil {
+		return err
+	}
+
+	r.p = o.UnwrapRefOrNil()
+	return nil
+}
+
+// Scan implements sql.Scanner like Option.
+func (r *OptRef[T]) Scan(src any) error {
+	var o Option[T]
+	if err := o.Scan(src); err != nil {
+		return err
+	}
+
+	r.p = o.UnwrapRefOrNil()
+	return nil
+}
+
+// Value implements driver.Valuer like Option.
+func (r OptRef[T]) Value() (driver.Value, error) {
+	return r.Deref().Value()
+}
@@ -0,0 +1,235 @@
+package goption
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"text/template"
+)
+
+type largeRecord struct {
+	ID   int
+	Data [64]byte
+}
+
+func TestOptRef(t *testing.T) {
+	var empty OptRef[int]
+	if empty.Ok() || empty.Ptr() != nil || empty.Deref().Ok() {
+		t.Errorf("Expected the zero value to be empty")
+	}
+	if p, ok := empty.Get(); ok || p != nil {
+		t.Errorf("Expected Get to be empty, got %v", p)
+	}
+	if o := FromPtr[int](nil); o.Ok() {
+		t.Errorf("Expected a nil pointer to be empty")
+	}
+
+	record := &largeRecord{ID: 1}
+	r := FromPtr(record)
+	if !r.Ok() || r.Ptr() != record || r.Unwrap() != record {
+		t.Errorf("Expected the pointer to be kept")
+	}
+
+	// Copies share the value.
+	copied := r
+	copied.Unwrap().ID = 2
+	if record.ID != 2 || r.Deref().Unwrap().ID != 2 {
+		t.Errorf("Expected the change to be shared")
+	}
+
+	// Deref copies it.
+	o := r.Deref()
+	o.UnwrapRef().ID = 3
+	if record.ID != 2 {
+		t.Errorf("Expected Deref to copy the value")
+	}
+}
+
+func TestOptRefUnwrapFail(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected to fail unwrapping empty optional")
+		}
+	}()
+	OptRef[int]{}.Unwrap()
+}
+
+func TestToOptRef(t *testing.T) {
+	o := Some(3)
+	r := ToOptRef(o)
+	*r.Unwrap() = 4
+	if o.Unwrap() != 3 || *r.Ptr() != 4 {
+		t.Errorf("Expected ToOptRef to copy the value")
+	}
+	if ToOptRef(None[int]()).Ok() {
+		t.Errorf("Expected none to convert to an empty OptRef")
+	}
+}
+
+func TestOptRefString(t *testing.T) {
+	if s := FromPtr(new(int)).String(); s != "0" {
+		t.Errorf("Unexpected string: %s", s)
+	}
+	if s := (OptRef[int]{}).String(); s != "null" {
+		t.Errorf("Unexpected string: %s", s)
+	}
+}
+
+func TestOptRefIsZero(t *testing.T) {
+	zero, notZero := fooZeroer(true), fooZeroer(false)
+	if !(OptRef[int]{}).IsZero() || FromPtr(new(int)).IsZero() {
+		t.Errorf("Expected only the empty OptRef to be zero")
+	}
+	if !FromPtr(&zero).IsZero() || FromPtr(&notZero).IsZero() {
+		t.Errorf("Expected IsZero to defer to the value")
+	}
+}
+
+func TestOptRefJSON(t *testing.T) {
+	type refs struct {
+		Count  OptRef[int]         `json:"count"`
+		Record OptRef[largeRecord] `json:"record,omitzero"`
+		Name   OptRef[string]      `json:"name"`
+	}
+
+	name := "jordan"
+	encoded, err := json.Marshal(refs{Count: FromPtr(new(int)), Name: FromPtr(&name)})
+	if err != nil {
+		t.Fatalf("Failed marshalling json: %s", err)
+	}
+	if string(encoded) != `{"count":0,"name":"jordan"}` {
+		t.Errorf("Unexpected encoded data: %s", encoded)
+	}
+
+	// Options and OptRefs encode alike.
+	for _, v := range []int{0, -5, 123} {
+		fromRef, _ := json.Marshal(FromPtr(&v))
+		fromOption, _ := json.Marshal(Some(v))
+		if string(fromRef) != string(fromOption) {
+			t.Errorf("Expected %s, got %s", fromOption, fromRef)
+		}
+	}
+
+	var decoded refs
+	decoded.Name = FromPtr(&name)
+	if err := json.Unmarshal([]byte(`{"count":3,"record":{"ID":7},"name":null}`), &decoded); err != nil {
+		t.Fatalf("Failed unmarshalling json: %s", err)
+	}
+	if *decoded.Count.Unwrap() != 3 || decoded.Record.Unwrap().ID != 7 || decoded.Name.Ok() {
+		t.Errorf("Unexpected decoded values: %+v", decoded)
+	}
+
+	// Unmarshalling allocates rather than writing through the pointer.
+	decoded.Name = FromPtr(&name)
+	if err := json.Unmarshal([]byte(`{"name":"other"}`), &decoded); err != nil {
+		t.Fatalf("Failed unmarshalling json: %s", err)
+	}
+	if name != "jordan" || *decoded.Name.Unwrap() != "other" {
+		t.Errorf("Expected a new value to be allocated")
+	}
+
+	if err := json.Unmarshal([]byte(`{"count":"x"}`), &decoded); err == nil {
+		t.Errorf("Expected an error unmarshalling a string into an int")
+	}
+	if *decoded.Count.Unwrap() != 3 {
+		t.Errorf("Expected a failed unmarshal to leave count alone")
+	}
+}
+
+func TestOptRefSQL(t *testing.T) {
+	var r OptRef[int32]
+	if err := r.Scan(int64(5)); err != nil || *r.Unwrap() != 5 {
+		t.Errorf("Failed scanning: %v", err)
+	}
+	if err := r.Scan(nil); err != nil || r.Ok() {
+		t.Errorf("Expected nil to scan as empty")
+	}
+	if err := r.Scan(struct{}{}); err != ErrNotAScanner {
+		t.Errorf("Expected ErrNotAScanner, got %v", err)
+	}
+
+	n := int32(7)
+	for _, tt := range []struct {
+		r    OptRef[int32]
+		want driver.Value
+	}{
+		{FromPtr(&n), int64(7)},
+		{OptRef[int32]{}, nil},
+	} {
+		if v, err := tt.r.Value(); err != nil || v != tt.want {
+			t.Errorf("Expected %v, got %v, %v", tt.want, v, err)
+		}
+	}
+}
+
+func TestOptRefAnyOption(t *testing.T) {
+	var _ AnyOption = OptRef[int]{}
+	if !IsOptionType(reflect.TypeFor[OptRef[int]]()) {
+		t.Errorf("Expected OptRef to be an option type")
+	}
+	if v, ok := ToOptRef(Some(3)).OptionAny(); !ok || v != 3 {
+		t.Errorf("Expected 3, got %v", v)
+	}
+	if v, ok := (OptRef[int]{}).OptionAny(); ok || v != 0 {
+		t.Errorf("Expected an empty zero value, got %v", v)
+	}
+
+	var r OptRef[int]
+	r.SetOptionAny(nil, true)
+	if p, ok := r.Get(); !ok || *p != 0 {
+		t.Errorf("Expected a present zero value")
+	}
+	r.SetOptionAny(1, false)
+	if r.Ok() {
+		t.Errorf("Expected SetOptionAny with !ok to empty it")
+	}
+
+	type config struct {
+		Port  OptRef[int]
+		Inner OptRef[struct{ Name Option[string] }]
+	}
+	src := config{Port: ToOptRef(Some(8080))}
+	src.Inner = FromPtr(&struct{ Name Option[string] }{Some("src")})
+	var dst config
+	Merge(&dst, src)
+	if dst.Port.Unwrap() == src.Port.Unwrap() || *dst.Port.Unwrap() != 8080 {
+		t.Errorf("Expected Port to be merged into a new value, got %v", dst.Port)
+	}
+	dst.Inner.Unwrap().Name = None[string]()
+	if src.Inner.Unwrap().Name.Unwrap() != "src" {
+		t.Errorf("Expected merging not to share the source's value")
+	}
+
+	if presence := Presence(dst); !presence["Port"] || !presence["Inner"] {
+		t.Errorf("Expected both fields to be present, got %v", presence)
+	}
+
+	changes := Diff(config{}, config{Port: ToOptRef(Some(1))})
+	if len(changes) != 1 || changes[0].String() != "Port: null -> 1" {
+		t.Errorf("Unexpected changes %v", changes)
+	}
+
+	var out strings.Builder
+	tmpl := template.Must(template.New("").Funcs(TemplateFuncs()).Parse(`{{unwrapOr 0 .Port}} {{isSome .Inner}}`))
+	if err := tmpl.Execute(&out, config{Port: ToOptRef(Some(1))}); err != nil {
+		t.Fatalf("Failed executing template: %s", err)
+	}
+	if out.String() != "1 false" {
+		t.Errorf("Expected %q, got %q", "1 false", out.String())
+	}
+}
+
+func TestOptRefText(t *testing.T) {
+	var r OptRef[int]
+	if err := r.UnmarshalText([]byte("42")); err != nil || *r.Unwrap() != 42 {
+		t.Errorf("Expected 42, got %v (%v)", r, err)
+	}
+	if text, err := r.MarshalText(); err != nil || string(text) != "42" {
+		t.Errorf("Expected 42, got %q (%v)", text, err)
+	}
+	if err := r.UnmarshalText([]byte("nope")); err == nil {
+		t.Errorf("Expected an error unmarshalling nope")
+	}
+}
@@ -0,0 +1,82 @@
+package goption
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+)
+
+// PanicError holds a value recovered from a panic along with the stack at
+// the point it was recovered.
+type PanicError struct {
+	Value any
+	Stack []byte
+}
+
+// Error implements error.
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// Unwrap returns the recovered value if it is an error.
+func (e *PanicError) Unwrap() error {
+	if err, isErr := e.Value.(error); isErr {
+		return err
+	}
+
+	return nil
+}
+
+// DoResult runs the function f which may panic.
+// If f does not panic Some(f()) is returned with a nil error.
+// Otherwise none is returned along with the recovered panic as a *PanicError,
+// which can be retrieved with errors.As.
+func DoResult[T any](f func() T) (o Option[T], err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			o = None[T]()
+			err = &PanicError{
+				Value: r,
+				Stack: debug.Stack(),
+			}
+		}
+	}()
+
+	o = Some(f())
+	return
+}
+
+// TryDo runs the function f which may panic, converting a panic into an error.
+// If f panics the returned error is a *PanicError.
+func TryDo[T any](f func() T) (T, error) {
+	o, err := DoResult(f)
+	return o.t, err
+}
+
+// DoCatch runs the function f, recovering only panics whose value is a P.
+// If f does not panic Some(f()) and None[P]() are returned. If f panics with
+// a P, None[T]() and Some(p) are returned. Any other panic, including every
+// runtime.Error, is re-raised so that real bugs aren't hidden.
+func DoCatch[P, T any](f func() T) (o Option[T], caught Option[P]) {
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+
+		if _, isRuntime := r.(runtime.Error); isRuntime {
+			panic(r)
+		}
+
+		p, isP := r.(P)
+		if !isP {
+			panic(r)
+		}
+
+		o = None[T]()
+		caught = Some(p)
+	}()
+
+	o = Some(f())
+	return
+}
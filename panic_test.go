@@ -0,0 +1,146 @@
+package goption
+
+import (
+	"errors"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestDoResult(t *testing.T) {
+	val, err := DoResult(func() int {
+		return 1
+	})
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	if val.Unwrap() != 1 {
+		t.Errorf("Expected 1, got %v", val)
+	}
+
+	val, err = DoResult(func() int {
+		var a *int
+		return *a
+	})
+	if val.Ok() {
+		t.Errorf("Expected empty optional, got %v", val)
+	}
+	if err == nil {
+		t.Fatalf("Expected a panic error")
+	}
+
+	var panicErr *PanicError
+	if !errors.As(err, &panicErr) {
+		t.Fatalf("Expected a *PanicError, got %T", err)
+	}
+
+	var runtimeErr runtime.Error
+	if !errors.As(err, &runtimeErr) {
+		t.Errorf("Expected the recovered value to be a runtime.Error, got %T", panicErr.Value)
+	}
+
+	if !strings.Contains(string(panicErr.Stack), "TestDoResult") {
+		t.Errorf("Expected the stack to include the panic site: %s", panicErr.Stack)
+	}
+
+	// Assigning to an existing error leaves it nil when f doesn't panic.
+	var existing error
+	val, existing = DoResult(func() int { return 2 })
+	if existing != nil || val != Some(2) {
+		t.Errorf("Expected Some(2) and a nil error, got %v, %v", val, existing)
+	}
+}
+
+func TestDoResultNonError(t *testing.T) {
+	_, err := DoResult(func() int {
+		panic("oops")
+	})
+	var panicErr *PanicError
+	if !errors.As(err, &panicErr) {
+		t.Fatalf("Expected a *PanicError, got %T", err)
+	}
+	if panicErr.Value != "oops" {
+		t.Errorf("Expected recovered value oops, got %v", panicErr.Value)
+	}
+	if errors.Unwrap(err) != nil {
+		t.Errorf("Expected no wrapped error, got %v", errors.Unwrap(err))
+	}
+	if err.Error() != "panic: oops" {
+		t.Errorf("Unexpected error message: %s", err.Error())
+	}
+}
+
+func TestTryDo(t *testing.T) {
+	val, err := TryDo(func() string {
+		return "hey!"
+	})
+	if err != nil || val != "hey!" {
+		t.Errorf("Expected hey! and no error, got %v, %v", val, err)
+	}
+
+	val, err = TryDo(func() string {
+		panic("oops")
+	})
+	if val != "" {
+		t.Errorf("Expected zero value, got %v", val)
+	}
+
+	var panicErr *PanicError
+	if !errors.As(err, &panicErr) {
+		t.Fatalf("Expected a *PanicError, got %T", err)
+	}
+	if panicErr.Value != "oops" {
+		t.Errorf("Expected recovered value oops, got %v", panicErr.Value)
+	}
+}
+
+type catchMe struct {
+	reason string
+}
+
+func TestDoCatch(t *testing.T) {
+	val, caught := DoCatch[catchMe](func() int {
+		return 1
+	})
+	if val.Unwrap() != 1 {
+		t.Errorf("Expected 1, got %v", val)
+	}
+	if caught.Ok() {
+		t.Errorf("Expected nothing caught, got %v", caught)
+	}
+
+	val, caught = DoCatch[catchMe](func() int {
+		panic(catchMe{reason: "bad input"})
+	})
+	if val.Ok() {
+		t.Errorf("Expected empty optional, got %v", val)
+	}
+	if caught.UnwrapOrDefault().reason != "bad input" {
+		t.Errorf("Expected to catch bad input, got %v", caught)
+	}
+}
+
+func TestDoCatchRepanics(t *testing.T) {
+	defer func() {
+		if r := recover(); r != "not caught" {
+			t.Errorf("Expected panic to propagate, got %v", r)
+		}
+	}()
+
+	DoCatch[catchMe](func() int {
+		panic("not caught")
+	})
+}
+
+func TestDoCatchRepanicsRuntimeError(t *testing.T) {
+	defer func() {
+		if _, isRuntime := recover().(runtime.Error); !isRuntime {
+			t.Errorf("Expected runtime.Error to propagate")
+		}
+	}()
+
+	DoCatch[error](func() int {
+		var a *int
+		return *a
+	})
+}
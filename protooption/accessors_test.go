@@ -0,0 +1,69 @@
+package protooption_test
+
+import (
+	"testing"
+
+	"github.com/jordan-bonecutter/goption"
+	"github.com/jordan-bonecutter/goption/internal/testpb"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestGeneratedAccessors(t *testing.T) {
+	var msg *testpb.Example
+	if msg.GetCountOption().Ok() || msg.GetWrappedCountOption().Ok() {
+		t.Errorf("Expected nil message to have empty options")
+	}
+
+	msg = &testpb.Example{}
+	if msg.GetCountOption().Ok() || msg.GetPayloadOption().Ok() || msg.GetWrappedNameOption().Ok() {
+		t.Errorf("Expected empty message to have empty options")
+	}
+
+	msg.SetCountOption(goption.Some[int32](0))
+	msg.SetNameOption(goption.Some("hey!"))
+	msg.SetPayloadOption(goption.Some[[]byte](nil))
+	msg.SetColorOption(goption.Some(testpb.Color_COLOR_RED))
+	msg.SetWrappedCountOption(goption.Some[int32](3))
+	msg.SetWrappedPayloadOption(goption.Some([]byte{1}))
+
+	encoded, err := proto.Marshal(msg)
+	if err != nil {
+		t.Fatalf("Failed marshalling message: %s", err)
+	}
+
+	decoded := &testpb.Example{}
+	if err := proto.Unmarshal(encoded, decoded); err != nil {
+		t.Fatalf("Failed unmarshalling message: %s", err)
+	}
+
+	if count := decoded.GetCountOption(); !count.Ok() || count.Unwrap() != 0 {
+		t.Errorf("Expected some 0, got %v", count)
+	}
+	if name := decoded.GetNameOption(); name.UnwrapOr("") != "hey!" {
+		t.Errorf("Expected hey!, got %v", name)
+	}
+	if payload := decoded.GetPayloadOption(); !payload.Ok() || len(payload.Unwrap()) != 0 {
+		t.Errorf("Expected some empty payload, got %v", payload)
+	}
+	if color := decoded.GetColorOption(); color.UnwrapOr(0) != testpb.Color_COLOR_RED {
+		t.Errorf("Expected red, got %v", color)
+	}
+	if ratio := decoded.GetRatioOption(); ratio.Ok() {
+		t.Errorf("Expected none, got %v", ratio)
+	}
+	if count := decoded.GetWrappedCountOption(); count.UnwrapOr(0) != 3 {
+		t.Errorf("Expected 3, got %v", count)
+	}
+	if name := decoded.GetWrappedNameOption(); name.Ok() {
+		t.Errorf("Expected none, got %v", name)
+	}
+	if payload := decoded.GetWrappedPayloadOption(); len(payload.UnwrapOrDefault()) != 1 {
+		t.Errorf("Expected [1], got %v", payload)
+	}
+
+	decoded.SetCountOption(goption.None[int32]())
+	decoded.SetWrappedCountOption(goption.None[int32]())
+	if decoded.Count != nil || decoded.WrappedCount != nil {
+		t.Errorf("Expected setting none to clear the fields")
+	}
+}
@@ -0,0 +1,83 @@
+// Package protooption converts between goption.Option and the ways that
+// generated protobuf messages represent optional fields.
+//
+// proto3 optional scalars are generated as *T, optional bytes as a []byte
+// which is nil when unset, and the well known wrapper types (such as
+// wrapperspb.Int32Value) as message pointers.
+//
+// The protoc-gen-go-option plugin generates accessors built on these functions.
+package protooption
+
+import (
+	"github.com/jordan-bonecutter/goption"
+	"google.golang.org/protobuf/proto"
+)
+
+// FromPointer returns the value of a proto3 optional field as an Option.
+func FromPointer[T any](p *T) goption.Option[T] {
+	return goption.FromRef(p)
+}
+
+// ToPointer returns a new pointer to the value of o, suitable for a proto3
+// optional field. If o is empty nil is returned.
+func ToPointer[T any](o goption.Option[T]) *T {
+	t, ok := o.Get()
+	if !ok {
+		return nil
+	}
+
+	return &t
+}
+
+// FromBytes returns the value of an optional bytes field as an Option.
+func FromBytes(b []byte) goption.Option[[]byte] {
+	if b == nil {
+		return goption.None[[]byte]()
+	}
+
+	return goption.Some(b)
+}
+
+// ToBytes returns the value of o, suitable for an optional bytes field.
+// A present but nil slice is returned as an empty, non-nil slice so that it
+// stays present.
+func ToBytes(o goption.Option[[]byte]) []byte {
+	b, ok := o.Get()
+	if !ok {
+		return nil
+	}
+	if b == nil {
+		return []byte{}
+	}
+
+	return b
+}
+
+// Wrapper is implemented by the well known wrapper messages in wrapperspb.
+type Wrapper[T any] interface {
+	proto.Message
+	GetValue() T
+}
+
+// FromWrapper returns the value held by a wrapper message as an Option.
+// A nil message returns an empty option.
+func FromWrapper[W Wrapper[T], T any](w W) goption.Option[T] {
+	if !w.ProtoReflect().IsValid() {
+		return goption.None[T]()
+	}
+
+	return goption.Some(w.GetValue())
+}
+
+// ToWrapper wraps the value of o using wrap, which is usually one of the
+// wrapperspb constructors such as wrapperspb.Int32.
+// If o is empty the zero W, a nil message, is returned.
+func ToWrapper[T any, W Wrapper[T]](o goption.Option[T], wrap func(T) W) W {
+	t, ok := o.Get()
+	if !ok {
+		var w W
+		return w
+	}
+
+	return wrap(t)
+}
@@ -0,0 +1,65 @@
+package protooption
+
+import (
+	"testing"
+
+	"github.com/jordan-bonecutter/goption"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestPointer(t *testing.T) {
+	if o := FromPointer[int32](nil); o.Ok() {
+		t.Errorf("Expected none for nil, got %v", o)
+	}
+
+	v := int32(3)
+	if o := FromPointer(&v); o.UnwrapOr(0) != 3 {
+		t.Errorf("Expected 3, got %v", o)
+	}
+
+	if p := ToPointer(goption.None[int32]()); p != nil {
+		t.Errorf("Expected nil, got %v", *p)
+	}
+
+	if p := ToPointer(goption.Some[int32](0)); p == nil || *p != 0 {
+		t.Errorf("Expected pointer to 0, got %v", p)
+	}
+}
+
+func TestBytes(t *testing.T) {
+	if o := FromBytes(nil); o.Ok() {
+		t.Errorf("Expected none for nil, got %v", o)
+	}
+	if o := FromBytes([]byte{}); !o.Ok() {
+		t.Errorf("Expected some for empty bytes")
+	}
+
+	if b := ToBytes(goption.None[[]byte]()); b != nil {
+		t.Errorf("Expected nil, got %v", b)
+	}
+	if b := ToBytes(goption.Some[[]byte](nil)); b == nil {
+		t.Errorf("Expected present nil bytes to stay present")
+	}
+	if b := ToBytes(goption.Some([]byte{1})); len(b) != 1 || b[0] != 1 {
+		t.Errorf("Expected [1], got %v", b)
+	}
+}
+
+func TestWrapper(t *testing.T) {
+	if o := FromWrapper((*wrapperspb.Int32Value)(nil)); o.Ok() {
+		t.Errorf("Expected none for nil, got %v", o)
+	}
+	if o := FromWrapper(wrapperspb.Int32(0)); !o.Ok() || o.Unwrap() != 0 {
+		t.Errorf("Expected some 0, got %v", o)
+	}
+	if o := FromWrapper(wrapperspb.String("hey!")); o.UnwrapOr("") != "hey!" {
+		t.Errorf("Expected hey!, got %v", o)
+	}
+
+	if w := ToWrapper(goption.None[int32](), wrapperspb.Int32); w != nil {
+		t.Errorf("Expected nil, got %v", w)
+	}
+	if w := ToWrapper(goption.Some[int32](3), wrapperspb.Int32); w.GetValue() != 3 {
+		t.Errorf("Expected 3, got %v", w)
+	}
+}
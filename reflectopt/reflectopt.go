@@ -0,0 +1,118 @@
+// Package reflectopt inspects and builds goption.Option values through
+// reflection, for serializers and ORMs which only have a reflect.Value.
+//
+// Types which embed an Option, such as goption.NonNull and the wrappers in
+// the encoder subpackages, are treated as options too.
+//
+//	for i := 0; i < v.NumField(); i++ {
+//	  field := v.Field(i)
+//	  if !reflectopt.IsOption(field.Type()) {
+//	    continue
+//	  }
+//	  if inner, ok := reflectopt.ValueOf(field); ok {
+//	    fmt.Println(v.Type().Field(i).Name, inner)
+//	  }
+//	}
+//
+// Like the reflect package, the functions panic when they are given a type
+// which is not an option, or a value which can't be read or set.
+package reflectopt
+
+import (
+	"reflect"
+
+	"github.com/jordan-bonecutter/goption"
+)
+
+// setter is implemented by every *goption.Option.
+type setter interface {
+	SetOptionAny(v any, ok bool)
+}
+
+var setterType = reflect.TypeFor[setter]()
+
+// IsOption returns true if t is an Option[T], or a struct embedding one.
+func IsOption(t reflect.Type) bool {
+	return goption.IsOptionType(t) && reflect.PointerTo(t).Implements(setterType)
+}
+
+// ElemType returns T for the Option[T] type t.
+// It panics if t is not an option.
+func ElemType(t reflect.Type) reflect.Type {
+	mustBeOption("ElemType", t)
+	return reflect.Zero(t).Interface().(goption.AnyOption).ElemType()
+}
+
+// IsSome returns true if the option v holds a value.
+// It panics if v is not an option.
+func IsSome(v reflect.Value) bool {
+	_, ok := get("IsSome", v)
+	return ok
+}
+
+// ValueOf returns the value held by the option v and true, or the zero value
+// of its element type and false if v is None. The returned value is not
+// addressable.
+// It panics if v is not an option.
+func ValueOf(v reflect.Value) (reflect.Value, bool) {
+	t, ok := get("ValueOf", v)
+	inner := reflect.New(ElemType(v.Type())).Elem()
+	if t != nil {
+		inner.Set(reflect.ValueOf(t))
+	}
+
+	return inner, ok
+}
+
+// SetSome sets the option v to Some(inner).
+// It panics if v is not a settable option, or if inner is not assignable to
+// its element type.
+func SetSome(v, inner reflect.Value) {
+	elem := ElemType(v.Type())
+	if inner.Type() != elem {
+		// Convert to T so that the type assertion in SetOptionAny succeeds.
+		converted := reflect.New(elem).Elem()
+		converted.Set(inner)
+		inner = converted
+	}
+
+	set("SetSome", v).SetOptionAny(inner.Interface(), true)
+}
+
+// SetNone sets the option v to None.
+// It panics if v is not a settable option.
+func SetNone(v reflect.Value) {
+	set("SetNone", v).SetOptionAny(nil, false)
+}
+
+// MakeSome returns a new Some value of the option type t holding inner.
+// It panics if t is not an option, or if inner is not assignable to its
+// element type.
+func MakeSome(t reflect.Type, inner reflect.Value) reflect.Value {
+	v := reflect.New(t).Elem()
+	SetSome(v, inner)
+	return v
+}
+
+// get returns the contents of the option v.
+func get(name string, v reflect.Value) (any, bool) {
+	mustBeOption(name, v.Type())
+	return v.Interface().(goption.AnyOption).OptionAny()
+}
+
+// set returns a setter which modifies the option v in place.
+func set(name string, v reflect.Value) setter {
+	mustBeOption(name, v.Type())
+	if !v.CanSet() {
+		panic("reflectopt." + name + ": option is not settable")
+	}
+
+	return v.Addr().Interface().(setter)
+}
+
+// mustBeOption panics if t is not an option.
+func mustBeOption(name string, t reflect.Type) {
+	if !IsOption(t) {
+		panic("reflectopt." + name + ": " + t.String() + " is not an Option")
+	}
+}
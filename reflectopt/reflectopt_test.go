@@ -0,0 +1,163 @@
+package reflectopt
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jordan-bonecutter/goption"
+	"github.com/jordan-bonecutter/goption/tomloption"
+)
+
+type record struct {
+	Name   string
+	Age    goption.Option[int]
+	Tags   goption.Option[[]string]
+	Any    goption.Option[fmt.Stringer]
+	Strict goption.NonNull[string]
+	Toml   tomloption.Option[float64]
+}
+
+type ids []int
+
+func TestIsOption(t *testing.T) {
+	for _, tt := range []struct {
+		t    reflect.Type
+		want bool
+	}{
+		{reflect.TypeFor[goption.Option[int]](), true},
+		{reflect.TypeFor[goption.Option[*record]](), true},
+		{reflect.TypeFor[goption.NonNull[string]](), true},
+		{reflect.TypeFor[tomloption.Option[int]](), true},
+		{reflect.TypeFor[*goption.Option[int]](), false},
+		{reflect.TypeFor[int](), false},
+		{reflect.TypeFor[record](), false},
+		{reflect.TypeFor[fmt.Stringer](), false},
+		{nil, false},
+	} {
+		if got := IsOption(tt.t); got != tt.want {
+			t.Errorf("IsOption(%v) = %v, want %v", tt.t, got, tt.want)
+		}
+	}
+}
+
+func TestElemType(t *testing.T) {
+	for _, tt := range []struct {
+		t    reflect.Type
+		want reflect.Type
+	}{
+		{reflect.TypeFor[goption.Option[int]](), reflect.TypeFor[int]()},
+		{reflect.TypeFor[goption.Option[[]string]](), reflect.TypeFor[[]string]()},
+		{reflect.TypeFor[goption.Option[fmt.Stringer]](), reflect.TypeFor[fmt.Stringer]()},
+		{reflect.TypeFor[goption.NonNull[string]](), reflect.TypeFor[string]()},
+		{reflect.TypeFor[tomloption.Option[float64]](), reflect.TypeFor[float64]()},
+	} {
+		if got := ElemType(tt.t); got != tt.want {
+			t.Errorf("ElemType(%v) = %v, want %v", tt.t, got, tt.want)
+		}
+	}
+}
+
+func TestValueOf(t *testing.T) {
+	r := record{
+		Age:    goption.Some(0),
+		Any:    goption.Some[fmt.Stringer](nil),
+		Strict: goption.NonNull[string]{Option: goption.Some("x")},
+	}
+	v := reflect.ValueOf(r)
+
+	for _, tt := range []struct {
+		field string
+		want  any
+		ok    bool
+	}{
+		{"Age", 0, true},
+		{"Tags", []string(nil), false},
+		{"Any", fmt.Stringer(nil), true},
+		{"Strict", "x", true},
+		{"Toml", 0.0, false},
+	} {
+		field := v.FieldByName(tt.field)
+		if IsSome(field) != tt.ok {
+			t.Errorf("IsSome(%s) = %v, want %v", tt.field, !tt.ok, tt.ok)
+		}
+
+		inner, ok := ValueOf(field)
+		if ok != tt.ok {
+			t.Errorf("ValueOf(%s) ok = %v, want %v", tt.field, ok, tt.ok)
+		}
+		if inner.Type() != ElemType(field.Type()) {
+			t.Errorf("ValueOf(%s) has type %v", tt.field, inner.Type())
+		}
+		if !reflect.DeepEqual(inner.Interface(), tt.want) {
+			t.Errorf("ValueOf(%s) = %#v, want %#v", tt.field, inner.Interface(), tt.want)
+		}
+	}
+}
+
+func TestSet(t *testing.T) {
+	var r record
+	v := reflect.ValueOf(&r).Elem()
+
+	SetSome(v.FieldByName("Age"), reflect.ValueOf(3))
+	SetSome(v.FieldByName("Tags"), reflect.ValueOf([]string{"a"}))
+	SetSome(v.FieldByName("Strict"), reflect.ValueOf("s"))
+	SetSome(v.FieldByName("Toml"), reflect.ValueOf(1.5))
+	if r.Age != goption.Some(3) || r.Tags.Unwrap()[0] != "a" || r.Strict.Unwrap() != "s" || r.Toml.Unwrap() != 1.5 {
+		t.Errorf("Unexpected record after SetSome: %+v", r)
+	}
+
+	// A concrete value is stored in an interface option.
+	SetSome(v.FieldByName("Any"), reflect.ValueOf(goption.Some(1)))
+	if r.Any != goption.Some[fmt.Stringer](goption.Some(1)) {
+		t.Errorf("Unexpected interface option: %v", r.Any)
+	}
+
+	SetNone(v.FieldByName("Age"))
+	SetNone(v.FieldByName("Strict"))
+	if r.Age.Ok() || r.Strict.Ok() {
+		t.Errorf("Expected SetNone to clear the options: %+v", r)
+	}
+}
+
+func TestSetAssignable(t *testing.T) {
+	var o goption.Option[[]int]
+	SetSome(reflect.ValueOf(&o).Elem(), reflect.ValueOf(ids{1, 2}))
+	if !reflect.DeepEqual(o, goption.Some([]int{1, 2})) {
+		t.Errorf("Unexpected option: %v", o)
+	}
+}
+
+func TestMakeSome(t *testing.T) {
+	v := MakeSome(reflect.TypeFor[goption.Option[string]](), reflect.ValueOf("hi"))
+	if o := v.Interface().(goption.Option[string]); o != goption.Some("hi") {
+		t.Errorf("Unexpected option: %v", o)
+	}
+}
+
+func TestPanics(t *testing.T) {
+	for name, f := range map[string]func(){
+		"reflectopt.ElemType: int is not an Option":  func() { ElemType(reflect.TypeFor[int]()) },
+		"reflectopt.IsSome: string is not an Option": func() { IsSome(reflect.ValueOf("x")) },
+		"reflectopt.SetSome: option is not settable": func() {
+			SetSome(reflect.ValueOf(goption.None[int]()), reflect.ValueOf(1))
+		},
+		"reflectopt.SetNone: option is not settable": func() { SetNone(reflect.ValueOf(goption.Some(1))) },
+		"reflect.Set: value of type string is not assignable to type int": func() {
+			MakeSome(reflect.TypeFor[goption.Option[int]](), reflect.ValueOf("x"))
+		},
+	} {
+		func() {
+			defer func() {
+				r := recover()
+				if r == nil {
+					t.Errorf("Expected a panic: %s", name)
+				} else if !strings.Contains(fmt.Sprint(r), name) {
+					t.Errorf("Unexpected panic %q, want %q", r, name)
+				}
+			}()
+			f()
+		}()
+	}
+}
@@ -0,0 +1,180 @@
+package goption_test
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/jordan-bonecutter/goption"
+	"github.com/jordan-bonecutter/goption/optiontest"
+	"gopkg.in/yaml.v3"
+)
+
+var yamlCodec = optiontest.Codec{
+	Name:      "yaml",
+	Marshal:   yaml.Marshal,
+	Unmarshal: yaml.Unmarshal,
+}
+
+// point is a custom encoding.TextMarshaler.
+type point struct {
+	X, Y int
+}
+
+func (p point) MarshalText() ([]byte, error) {
+	return fmt.Appendf(nil, "%d,%d", p.X, p.Y), nil
+}
+
+func (p *point) UnmarshalText(data []byte) error {
+	_, err := fmt.Sscanf(string(data), "%d,%d", &p.X, &p.Y)
+	return err
+}
+
+type record struct {
+	Name   string                                `json:"name" yaml:"name"`
+	Count  goption.Option[int]                   `json:"count" yaml:"count"`
+	Tags   goption.Option[[]string]              `json:"tags" yaml:"tags"`
+	When   goption.Option[time.Time]             `json:"when" yaml:"when"`
+	Where  goption.Option[point]                 `json:"where" yaml:"where"`
+	Nested goption.Option[goption.Option[uint8]] `json:"nested" yaml:"nested"`
+	Inner  goption.Option[*record]               `json:"inner" yaml:"inner"`
+}
+
+const propertyValues = 200
+
+// generate returns options with values from gen, a quarter of which are None.
+func generate[T any](gen func(*rand.Rand) T) []goption.Option[T] {
+	rand := rand.New(rand.NewSource(1))
+	values := make([]goption.Option[T], propertyValues)
+	for i := range values {
+		if rand.Intn(4) != 0 {
+			values[i] = goption.Some(gen(rand))
+		}
+	}
+	return values
+}
+
+// generateQuick returns options with values from testing/quick.
+func generateQuick[T any]() []goption.Option[T] {
+	rand := rand.New(rand.NewSource(1))
+	values := make([]goption.Option[T], propertyValues)
+	for i := range values {
+		values[i] = optiontest.Generate[T](rand, 10)
+	}
+	return values
+}
+
+func randomTime(rand *rand.Rand) time.Time {
+	return time.Unix(rand.Int63n(1<<35), rand.Int63n(int64(time.Second))).UTC()
+}
+
+func randomPoint(rand *rand.Rand) point {
+	return point{X: rand.Intn(2000) - 1000, Y: rand.Intn(2000) - 1000}
+}
+
+// randomNested never returns Some(None), which every codec encodes as None.
+func randomNested(rand *rand.Rand) goption.Option[uint8] {
+	return goption.Some(uint8(rand.Intn(256)))
+}
+
+func randomRecord(rand *rand.Rand) record {
+	opt := func() bool { return rand.Intn(2) == 0 }
+	r := record{Name: fmt.Sprint(rand.Int())}
+	if opt() {
+		r.Count = goption.Some(rand.Int())
+	}
+	if opt() {
+		r.Tags = goption.Some([]string{fmt.Sprint(rand.Int()), ""})
+	}
+	if opt() {
+		r.When = goption.Some(randomTime(rand))
+	}
+	if opt() {
+		r.Where = goption.Some(randomPoint(rand))
+	}
+	if opt() {
+		r.Nested = goption.Some(randomNested(rand))
+	}
+	if rand.Intn(4) == 0 {
+		inner := randomRecord(rand)
+		r.Inner = goption.Some(&inner)
+	}
+	return r
+}
+
+// roundTrip checks that the values survive each codec.
+func roundTrip[T any](t *testing.T, values []T, codecs ...optiontest.Codec) {
+	t.Helper()
+	for _, c := range codecs {
+		optiontest.RoundTrip(t, c, values)
+	}
+}
+
+func TestRoundTripScalars(t *testing.T) {
+	codecs := []optiontest.Codec{optiontest.JSON, optiontest.Text, yamlCodec}
+	roundTrip(t, generateQuick[bool](), codecs...)
+	roundTrip(t, generateQuick[int](), codecs...)
+	roundTrip(t, generateQuick[int8](), codecs...)
+	roundTrip(t, generateQuick[uint64](), codecs...)
+	roundTrip(t, generateQuick[float32](), codecs...)
+	roundTrip(t, generateQuick[float64](), codecs...)
+	roundTrip(t, generateQuick[string](), codecs...)
+	roundTrip(t, generateQuick[[]byte](), codecs...)
+}
+
+func TestRoundTripComposites(t *testing.T) {
+	codecs := []optiontest.Codec{optiontest.JSON, optiontest.Text, yamlCodec}
+	roundTrip(t, generateQuick[[]int](), codecs...)
+	roundTrip(t, generateQuick[map[string]int](), codecs...)
+	roundTrip(t, generate(randomTime), codecs...)
+	roundTrip(t, generate(randomPoint), codecs...)
+	roundTrip(t, generate(randomNested), codecs...)
+	roundTrip(t, generate(randomRecord), codecs...)
+}
+
+func TestRoundTripStructs(t *testing.T) {
+	rand := rand.New(rand.NewSource(1))
+	records := make([]record, propertyValues)
+	for i := range records {
+		records[i] = randomRecord(rand)
+	}
+
+	roundTrip(t, records, optiontest.JSON, yamlCodec)
+}
+
+// roundTripSQL checks that the values survive driver.Valuer followed by
+// sql.Scanner.
+func roundTripSQL[T comparable](t *testing.T, values []goption.Option[T]) {
+	t.Helper()
+	for _, v := range values {
+		value, err := v.Value()
+		if err != nil {
+			t.Errorf("sql: failed getting value of %#v: %s", v, err)
+			continue
+		}
+
+		var scanned goption.Option[T]
+		if err := scanned.Scan(value); err != nil {
+			t.Errorf("sql: failed scanning %#v: %s", value, err)
+			continue
+		}
+
+		if scanned != v {
+			t.Errorf("sql: %#v didn't round trip, got %#v", v, scanned)
+		}
+	}
+}
+
+func TestRoundTripSQL(t *testing.T) {
+	roundTripSQL(t, generateQuick[bool]())
+	roundTripSQL(t, generateQuick[int]())
+	roundTripSQL(t, generateQuick[int8]())
+	// uint64 values above math.MaxInt64 have no driver.Value.
+	roundTripSQL(t, generateQuick[uint32]())
+	roundTripSQL(t, generateQuick[float32]())
+	roundTripSQL(t, generateQuick[float64]())
+	roundTripSQL(t, generateQuick[string]())
+	roundTripSQL(t, generate(randomTime))
+	roundTripSQL(t, generate(randomPoint))
+}
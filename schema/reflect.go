@@ -0,0 +1,237 @@
+package schema
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/jordan-bonecutter/goption"
+	"github.com/jordan-bonecutter/goption/reflectopt"
+)
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	nonNullType       = reflect.TypeFor[goption.NonNullOption]()
+)
+
+// OptionElem returns T if t is an Option[T], and false otherwise. Types which
+// embed an Option, such as the wrappers in the encoder subpackages, are
+// options too, except for goption.NonNull which is never null.
+func OptionElem(t reflect.Type) (reflect.Type, bool) {
+	if !reflectopt.IsOption(t) || t.Implements(nonNullType) {
+		return nil, false
+	}
+
+	return reflectopt.ElemType(t), true
+}
+
+// nonNullElem returns T if t is a goption.NonNull[T], or embeds one.
+func nonNullElem(t reflect.Type) (reflect.Type, bool) {
+	if !reflectopt.IsOption(t) || !t.Implements(nonNullType) {
+		return nil, false
+	}
+
+	return reflectopt.ElemType(t), true
+}
+
+// reflector builds schemas for Go types, collecting named structs as definitions.
+type reflector struct {
+	refPrefix string
+	defs      map[string]*Schema
+	names     map[reflect.Type]string
+
+	// inline describes structs in place instead of as definitions.
+	// Recursive structs are cut off with an empty schema.
+	inline    bool
+	expanding map[reflect.Type]bool
+}
+
+func newReflector(refPrefix string) *reflector {
+	return &reflector{
+		refPrefix: refPrefix,
+		defs:      map[string]*Schema{},
+		names:     map[reflect.Type]string{},
+		expanding: map[reflect.Type]bool{},
+	}
+}
+
+// schemaFor returns the schema of t.
+func (r *reflector) schemaFor(t reflect.Type) *Schema {
+	if t == nil {
+		return &Schema{}
+	}
+
+	if elem, isOption := OptionElem(t); isOption {
+		return nullable(r.schemaFor(elem))
+	}
+	if elem, isNonNull := nonNullElem(t); isNonNull {
+		return r.schemaFor(elem)
+	}
+
+	switch {
+	case t == timeType:
+		return &Schema{Type: Types{"string"}, Format: "date-time"}
+	case t.Implements(jsonMarshalerType) || reflect.PointerTo(t).Implements(jsonMarshalerType):
+		// The encoding could be anything.
+		return &Schema{}
+	case t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType):
+		return &Schema{Type: Types{"string"}}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: Types{"boolean"}}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return &Schema{Type: Types{"integer"}}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: Types{"number"}}
+	case reflect.String:
+		return &Schema{Type: Types{"string"}}
+	case reflect.Pointer:
+		return nullable(r.schemaFor(t.Elem()))
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 && t.Kind() == reflect.Slice {
+			return &Schema{Type: Types{"string"}, ContentEncoding: "base64"}
+		}
+		return &Schema{Type: Types{"array"}, Items: r.schemaFor(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: Types{"object"}, AdditionalProperties: r.schemaFor(t.Elem())}
+	case reflect.Struct:
+		return r.structSchema(t)
+	}
+
+	// Interfaces, funcs and channels can't be described.
+	return &Schema{}
+}
+
+// structSchema returns the schema of the struct t, referencing a definition
+// if t is named.
+func (r *reflector) structSchema(t reflect.Type) *Schema {
+	if r.inline {
+		if r.expanding[t] {
+			return &Schema{}
+		}
+		r.expanding[t] = true
+		defer delete(r.expanding, t)
+		return r.objectSchema(t)
+	}
+
+	if t.Name() == "" {
+		return r.objectSchema(t)
+	}
+
+	if name, defined := r.names[t]; defined {
+		return &Schema{Ref: r.refPrefix + name}
+	}
+
+	// Claim the name before describing the fields in case they refer back to t.
+	name := r.defName(t)
+	def := &Schema{}
+	r.names[t] = name
+	r.defs[name] = def
+	*def = *r.objectSchema(t)
+	return &Schema{Ref: r.refPrefix + name}
+}
+
+// defName returns a unique definition name for t.
+func (r *reflector) defName(t reflect.Type) string {
+	name := sanitize(t.Name())
+	if _, taken := r.defs[name]; !taken {
+		return name
+	}
+
+	return sanitize(t.String())
+}
+
+// sanitize replaces characters which aren't allowed in component names.
+func sanitize(name string) string {
+	return strings.Map(func(c rune) rune {
+		if unicode.IsLetter(c) || unicode.IsDigit(c) || c == '_' || c == '-' || c == '.' {
+			return c
+		}
+		return '_'
+	}, name)
+}
+
+// objectSchema returns the schema describing the fields of the struct t.
+func (r *reflector) objectSchema(t reflect.Type) *Schema {
+	s := &Schema{
+		Type:       Types{"object"},
+		Properties: map[string]*Schema{},
+	}
+	r.addFields(s, t)
+	return s
+}
+
+// addFields adds the fields of the struct t to s, flattening embedded structs
+// the way encoding/json does.
+func (r *reflector) addFields(s *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if _, isOption := OptionElem(embedded); !isOption && embedded.Kind() == reflect.Struct {
+				r.addFields(s, embedded)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		s.Properties[name] = r.schemaFor(field.Type)
+
+		_, isOption := OptionElem(field.Type)
+		_, isNonNull := nonNullElem(field.Type)
+		if !isOption && !isNonNull && !hasOpt(opts, "omitempty") && !hasOpt(opts, "omitzero") {
+			s.Required = append(s.Required, name)
+		}
+	}
+}
+
+// hasOpt returns true if the comma separated json tag options contain opt.
+func hasOpt(opts, opt string) bool {
+	for opts != "" {
+		var o string
+		o, opts, _ = strings.Cut(opts, ",")
+		if o == opt {
+			return true
+		}
+	}
+
+	return false
+}
+
+// nullable returns a schema which also accepts null.
+func nullable(s *Schema) *Schema {
+	switch {
+	case s.Ref != "" || len(s.AnyOf) > 0:
+		return &Schema{AnyOf: []*Schema{s, {Type: Types{"null"}}}}
+	case len(s.Type) == 0, slices.Contains(s.Type, "null"):
+		// Already accepts null.
+		return s
+	}
+
+	n := *s
+	n.Type = append(slices.Clone(s.Type), "null")
+	return &n
+}
@@ -0,0 +1,115 @@
+// Package schema generates JSON Schema (draft 2020-12) and OpenAPI 3.1
+// component schemas from Go types, understanding goption.Option fields.
+//
+// An Option[T] field becomes a nullable T which is never required, and a
+// NonNull[T] field becomes a T which is never required. Other fields are
+// required unless they are tagged with omitempty or omitzero.
+// Field names follow encoding/json.
+//
+// Other generators can use Hook to describe Option types. For example with
+// github.com/invopop/jsonschema:
+//
+//	reflector.Mapper = func(t reflect.Type) *jsonschema.Schema {
+//	  s, ok := schema.Hook(t)
+//	  if !ok {
+//	    return nil
+//	  }
+//	  var converted jsonschema.Schema
+//	  data, _ := json.Marshal(s)
+//	  json.Unmarshal(data, &converted)
+//	  return &converted
+//	}
+//
+// and with github.com/getkin/kin-openapi/openapi3gen, where OptionElem lets a
+// SchemaCustomizer recognise Option fields.
+package schema
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// Draft is the JSON Schema dialect of the generated schemas.
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema is a JSON Schema.
+// Only the keywords needed to describe Go types are included.
+type Schema struct {
+	Schema               string             `json:"$schema,omitempty"`
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 Types              `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	ContentEncoding      string             `json:"contentEncoding,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	AnyOf                []*Schema          `json:"anyOf,omitempty"`
+	Defs                 map[string]*Schema `json:"$defs,omitempty"`
+}
+
+// Types is the type keyword of a Schema.
+// A single type is marshalled as a string, and several as an array.
+type Types []string
+
+// MarshalJSON implements json.Marshaler.
+func (t Types) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return json.Marshal(t[0])
+	}
+
+	return json.Marshal([]string(t))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *Types) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*t = Types{single}
+		return nil
+	}
+
+	return json.Unmarshal(data, (*[]string)(t))
+}
+
+// Components holds the schemas of an OpenAPI 3.1 components object.
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+// JSONSchema returns a JSON Schema document describing the type of v.
+// Named struct types are described in $defs.
+func JSONSchema(v any) *Schema {
+	r := newReflector("#/$defs/")
+	root := r.schemaFor(reflect.TypeOf(v))
+	root.Schema = Draft
+	if len(r.defs) > 0 {
+		root.Defs = r.defs
+	}
+
+	return root
+}
+
+// OpenAPI returns OpenAPI 3.1 components describing the types of vs.
+// Each named struct type is a component schema, and references between them
+// point into #/components/schemas.
+func OpenAPI(vs ...any) *Components {
+	r := newReflector("#/components/schemas/")
+	for _, v := range vs {
+		r.schemaFor(reflect.TypeOf(v))
+	}
+
+	return &Components{Schemas: r.defs}
+}
+
+// Hook returns the schema of t if it is an Option, and false otherwise.
+// Structs are described inline so that the schema is self contained.
+func Hook(t reflect.Type) (*Schema, bool) {
+	if _, isOption := OptionElem(t); !isOption {
+		return nil, false
+	}
+
+	r := newReflector("")
+	r.inline = true
+	return r.schemaFor(t), true
+}
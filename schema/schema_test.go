@@ -0,0 +1,132 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jordan-bonecutter/goption"
+	"github.com/jordan-bonecutter/goption/tomloption"
+)
+
+type address struct {
+	Street string                 `json:"street"`
+	Unit   goption.Option[string] `json:"unit"`
+}
+
+type Base struct {
+	ID int `json:"id"`
+}
+
+type user struct {
+	Base
+	Name       string                         `json:"name"`
+	Nickname   goption.Option[string]         `json:"nickname"`
+	Age        goption.Option[int]            `json:"age,omitempty"`
+	Address    goption.Option[address]        `json:"address"`
+	Previous   []address                      `json:"previous,omitempty"`
+	Tags       map[string]goption.Option[int] `json:"tags"`
+	Created    time.Time                      `json:"created"`
+	Avatar     []byte                         `json:"avatar,omitzero"`
+	Manager    *user                          `json:"manager"`
+	Port       tomloption.Option[int]         `json:"port"`
+	Email      goption.NonNull[string]        `json:"email"`
+	Referrer   goption.OptRef[address]        `json:"referrer"`
+	Ignored    string                         `json:"-"`
+	unexported int
+}
+
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+func golden(t *testing.T, name string, v any) {
+	t.Helper()
+
+	encoded, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		t.Fatalf("Failed marshalling schema: %s", err)
+	}
+	encoded = append(encoded, '\n')
+
+	if *update {
+		if err := os.WriteFile("testdata/"+name, encoded, 0o644); err != nil {
+			t.Fatalf("Failed updating golden file: %s", err)
+		}
+	}
+
+	expected, err := os.ReadFile("testdata/" + name)
+	if err != nil {
+		t.Fatalf("Failed reading golden file: %s", err)
+	}
+
+	if !bytes.Equal(encoded, expected) {
+		t.Errorf("Unexpected schema:\n%s\nexpected:\n%s", encoded, expected)
+	}
+}
+
+func TestJSONSchema(t *testing.T) {
+	golden(t, "user.schema.json", JSONSchema(user{}))
+}
+
+func TestOpenAPI(t *testing.T) {
+	golden(t, "components.json", OpenAPI(user{}, Base{}))
+}
+
+func TestOptionElem(t *testing.T) {
+	if elem, ok := OptionElem(reflect.TypeOf(goption.None[int]())); !ok || elem != reflect.TypeOf(0) {
+		t.Errorf("Expected Option[int] to have elem int, got %v", elem)
+	}
+	if elem, ok := OptionElem(reflect.TypeOf(tomloption.None[string]())); !ok || elem != reflect.TypeOf("") {
+		t.Errorf("Expected tomloption.Option[string] to have elem string, got %v", elem)
+	}
+	if elem, ok := OptionElem(reflect.TypeFor[struct{ goption.Option[bool] }]()); !ok || elem != reflect.TypeOf(false) {
+		t.Errorf("Expected a struct embedding Option[bool] to have elem bool, got %v", elem)
+	}
+	if elem, ok := OptionElem(reflect.TypeFor[goption.OptRef[string]]()); !ok || elem != reflect.TypeOf("") {
+		t.Errorf("Expected OptRef[string] to have elem string, got %v", elem)
+	}
+	if _, ok := OptionElem(reflect.TypeFor[goption.NonNull[int]]()); ok {
+		t.Errorf("Expected NonNull not to be a nullable option")
+	}
+	if elem, ok := nonNullElem(reflect.TypeFor[struct{ goption.NonNull[int] }]()); !ok || elem != reflect.TypeOf(0) {
+		t.Errorf("Expected a struct embedding NonNull[int] to be non null with elem int, got %v", elem)
+	}
+	if _, ok := OptionElem(reflect.TypeOf(address{})); ok {
+		t.Errorf("Expected address not to be an option")
+	}
+	if _, ok := OptionElem(nil); ok {
+		t.Errorf("Expected nil not to be an option")
+	}
+}
+
+func TestHook(t *testing.T) {
+	if _, ok := Hook(reflect.TypeOf(0)); ok {
+		t.Errorf("Expected int not to be hooked")
+	}
+
+	s, ok := Hook(reflect.TypeOf(goption.None[int]()))
+	if !ok {
+		t.Fatalf("Expected Option[int] to be hooked")
+	}
+	if !reflect.DeepEqual(s.Type, Types{"integer", "null"}) {
+		t.Errorf("Expected nullable integer, got %v", s.Type)
+	}
+
+	golden(t, "hook.json", func() *Schema {
+		s, _ := Hook(reflect.TypeOf(goption.None[user]()))
+		return s
+	}())
+}
+
+func TestTypesUnmarshal(t *testing.T) {
+	var s Schema
+	if err := json.Unmarshal([]byte(`{"type":"string"}`), &s); err != nil || !reflect.DeepEqual(s.Type, Types{"string"}) {
+		t.Errorf("Failed unmarshalling single type: %v, %v", s.Type, err)
+	}
+	if err := json.Unmarshal([]byte(`{"type":["string","null"]}`), &s); err != nil || !reflect.DeepEqual(s.Type, Types{"string", "null"}) {
+		t.Errorf("Failed unmarshalling types: %v, %v", s.Type, err)
+	}
+}
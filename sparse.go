@@ -0,0 +1,134 @@
+package goption
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"strconv"
+)
+
+// Sparse is a slice of options, mostly expected to be empty. It marshals to
+// JSON as its length and an object of its present values keyed by index,
+// such as {"len":5,"values":{"1":"a","3":"b"}}, and unmarshals from either
+// that or an array like a []Option[T].
+type Sparse[T any] []Option[T]
+
+// MaxSparseLen is the largest length Sparse.UnmarshalJSON accepts, since a
+// few bytes such as {"len":1e18} would otherwise allocate an arbitrarily
+// large slice. Use UnmarshalJSONMaxLen to decode longer trusted input.
+const MaxSparseLen = 1 << 20
+
+// Get returns the value at i, or none if it's empty or i is out of range.
+func (s Sparse[T]) Get(i int) Option[T] {
+	if i < 0 || i >= len(s) {
+		return None[T]()
+	}
+
+	return s[i]
+}
+
+// Set sets the value at i to t, growing s with empty values if needed.
+// It panics if i is negative.
+func (s *Sparse[T]) Set(i int, t T) {
+	if i < 0 {
+		panic(fmt.Sprintf("goption: Sparse.Set at negative index %d", i))
+	}
+	if i >= len(*s) {
+		*s = append(*s, make(Sparse[T], i+1-len(*s))...)
+	}
+
+	(*s)[i] = Some(t)
+}
+
+// Count returns the number of present values in s.
+func (s Sparse[T]) Count() int {
+	count := 0
+	for _, o := range s {
+		if o.ok {
+			count++
+		}
+	}
+
+	return count
+}
+
+// All yields the index and value of every present value in s, in order.
+func (s Sparse[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i, o := range s {
+			if o.ok && !yield(i, o.t) {
+				return
+			}
+		}
+	}
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s Sparse[T]) MarshalJSON() ([]byte, error) {
+	buf := append([]byte(`{"len":`), strconv.Itoa(len(s))...)
+	buf = append(buf, `,"values":{`...)
+	first := true
+	for i, o := range s {
+		if !o.ok {
+			continue
+		}
+
+		data, err := o.MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		if !first {
+			buf = append(buf, ',')
+		}
+		first = false
+		buf = append(buf, '"')
+		buf = strconv.AppendInt(buf, int64(i), 10)
+		buf = append(buf, `":`...)
+		buf = append(buf, data...)
+	}
+
+	return append(buf, "}}"...), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It fails if the length is
+// greater than MaxSparseLen.
+func (s *Sparse[T]) UnmarshalJSON(data []byte) error {
+	return s.UnmarshalJSONMaxLen(data, MaxSparseLen)
+}
+
+// UnmarshalJSONMaxLen is like UnmarshalJSON, but fails if the length is
+// greater than maxLen instead.
+func (s *Sparse[T]) UnmarshalJSONMaxLen(data []byte, maxLen int) error {
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		return json.Unmarshal(trimmed, (*[]Option[T])(s))
+	}
+	if string(data) == "null" {
+		return nil
+	}
+
+	var compact struct {
+		Len    int               `json:"len"`
+		Values map[int]Option[T] `json:"values"`
+	}
+	if err := json.Unmarshal(data, &compact); err != nil {
+		return err
+	}
+	if compact.Len < 0 {
+		return fmt.Errorf("goption: Sparse length %d is negative", compact.Len)
+	}
+	if compact.Len > maxLen {
+		return fmt.Errorf("goption: Sparse length %d is greater than the maximum of %d", compact.Len, maxLen)
+	}
+
+	sparse := make(Sparse[T], compact.Len)
+	for i, o := range compact.Values {
+		if i < 0 || i >= compact.Len {
+			return fmt.Errorf("goption: Sparse index %d is out of range for length %d", i, compact.Len)
+		}
+		sparse[i] = o
+	}
+
+	*s = sparse
+	return nil
+}
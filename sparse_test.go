@@ -0,0 +1,111 @@
+package goption
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSparse(t *testing.T) {
+	var s Sparse[string]
+	s.Set(3, "d")
+	s.Set(1, "b")
+	if len(s) != 4 || s.Count() != 2 {
+		t.Errorf("Unexpected sparse slice: %v", s)
+	}
+
+	for i, want := range []Option[string]{None[string](), Some("b"), None[string](), Some("d"), None[string]()} {
+		if o := s.Get(i); o != want {
+			t.Errorf("Expected %v at %d, got %v", want, i, o)
+		}
+	}
+	if o := s.Get(-1); o.Ok() {
+		t.Errorf("Expected none at a negative index")
+	}
+
+	if all := maps.Collect(s.All()); !maps.Equal(all, map[int]string{1: "b", 3: "d"}) {
+		t.Errorf("Unexpected values: %v", all)
+	}
+}
+
+func TestSparseSetNegative(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected to fail setting a negative index")
+		}
+	}()
+	var s Sparse[int]
+	s.Set(-1, 0)
+}
+
+func TestSparseJSON(t *testing.T) {
+	s := make(Sparse[int], 12)
+	s[2] = Some(0)
+	s[10] = Some(7)
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatalf("Failed marshalling: %s", err)
+	}
+	if string(data) != `{"len":12,"values":{"2":0,"10":7}}` {
+		t.Errorf("Unexpected JSON: %s", data)
+	}
+
+	var decoded Sparse[int]
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Failed unmarshalling: %s", err)
+	}
+	if !reflect.DeepEqual(decoded, s) {
+		t.Errorf("Expected %v, got %v", s, decoded)
+	}
+
+	if data, _ := json.Marshal(Sparse[int]{}); string(data) != `{"len":0,"values":{}}` {
+		t.Errorf("Unexpected JSON for an empty slice: %s", data)
+	}
+}
+
+func TestSparseUnmarshalArray(t *testing.T) {
+	var s Sparse[string]
+	if err := json.Unmarshal([]byte(` [null, "a", null]`), &s); err != nil {
+		t.Fatalf("Failed unmarshalling: %s", err)
+	}
+	if !reflect.DeepEqual(s, Sparse[string]{None[string](), Some("a"), None[string]()}) {
+		t.Errorf("Unexpected sparse slice: %v", s)
+	}
+}
+
+func TestSparseUnmarshalErrors(t *testing.T) {
+	for data, want := range map[string]string{
+		`{"len":2,"values":{"2":1}}`:              "goption: Sparse index 2 is out of range for length 2",
+		`{"len":2,"values":{"-1":1}}`:             "goption: Sparse index -1 is out of range for length 2",
+		`{"len":-1}`:                              "goption: Sparse length -1 is negative",
+		`{"len":9223372036854775807,"values":{}}`: "goption: Sparse length 9223372036854775807 is greater than the maximum of 1048576",
+		`{"len":1,"values":{"0":"a"}}`:            "json: cannot unmarshal",
+	} {
+		var s Sparse[int]
+		if err := json.Unmarshal([]byte(data), &s); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected %q for %s, got %v", want, data, err)
+		}
+	}
+}
+
+func TestSparseMaxLen(t *testing.T) {
+	var s Sparse[int]
+	if err := s.UnmarshalJSONMaxLen([]byte(`{"len":2,"values":{"1":1}}`), 2); err != nil || len(s) != 2 {
+		t.Errorf("Expected a length of 2 to be accepted, got %v, %v", s, err)
+	}
+	if err := s.UnmarshalJSONMaxLen([]byte(`{"len":3}`), 2); err == nil || err.Error() != "goption: Sparse length 3 is greater than the maximum of 2" {
+		t.Errorf("Expected a length of 3 to be rejected, got %v", err)
+	}
+
+	large := fmt.Sprintf(`{"len":%d}`, MaxSparseLen+1)
+	if err := json.Unmarshal([]byte(large), &s); err == nil {
+		t.Errorf("Expected a length above MaxSparseLen to be rejected")
+	}
+	if err := s.UnmarshalJSONMaxLen([]byte(large), MaxSparseLen+1); err != nil || len(s) != MaxSparseLen+1 {
+		t.Errorf("Expected a larger maximum to be accepted, got %v", err)
+	}
+}
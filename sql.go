@@ -3,6 +3,8 @@ package goption
 import (
 	"database/sql"
 	"database/sql/driver"
+	"fmt"
+	"math"
 	"reflect"
 	"time"
 )
@@ -51,27 +53,29 @@ func (o Option[T]) Value() (driver.Value, error) {
 		return valuer.Value()
 	}
 
+	// Check the kind first, since numbers convert to each other and strings.
 	tVal := reflect.ValueOf(o.t)
-	int64Type := reflect.TypeOf(int64(0))
-	if tVal.CanConvert(int64Type) {
-		return tVal.Convert(int64Type).Interface(), nil
-	}
-	f64Type := reflect.TypeOf(float64(0))
-	if tVal.CanConvert(f64Type) {
-		return tVal.Convert(f64Type).Interface(), nil
-	}
-	boolType := reflect.TypeOf(false)
-	if tVal.CanConvert(boolType) {
-		return tVal.Convert(boolType).Interface(), nil
+	switch tVal.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return tVal.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		// Like database/sql's default converter, refuse values int64 can't hold.
+		u := tVal.Uint()
+		if u > math.MaxInt64 {
+			return nil, fmt.Errorf("goption: uint64 value %d is too large for a driver.Value", u)
+		}
+		return int64(u), nil
+	case reflect.Float32, reflect.Float64:
+		return tVal.Float(), nil
+	case reflect.Bool:
+		return tVal.Bool(), nil
+	case reflect.String:
+		return tVal.String(), nil
 	}
 	bytesType := reflect.TypeOf([]byte(nil))
 	if tVal.CanConvert(bytesType) {
 		return tVal.Convert(bytesType).Interface(), nil
 	}
-	stringType := reflect.TypeOf("")
-	if tVal.CanConvert(stringType) {
-		return tVal.Convert(stringType).Interface(), nil
-	}
 	timeType := reflect.TypeOf(time.Time{})
 	if tVal.CanConvert(timeType) {
 		return tVal.Convert(timeType).Interface(), nil
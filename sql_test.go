@@ -3,6 +3,8 @@ package goption
 import (
 	"database/sql"
 	"database/sql/driver"
+	"math"
+	"reflect"
 	"testing"
 	"time"
 
@@ -157,3 +159,40 @@ func TestDummyImplementsDriverValuer(t *testing.T) {
 	valuer = dummyValuer{}
 	func(any) {}(valuer)
 }
+
+func TestValueKinds(t *testing.T) {
+	type myString string
+
+	for _, test := range []struct {
+		opt      driver.Valuer
+		expected driver.Value
+	}{
+		{None[int](), nil},
+		{Some(int8(-3)), int64(-3)},
+		{Some(uint32(3)), int64(3)},
+		{Some(uint64(math.MaxInt64)), int64(math.MaxInt64)},
+		{Some(float32(1.5)), float64(1.5)},
+		{Some(1e300), float64(1e300)},
+		{Some(true), true},
+		{Some(myString("hey!")), "hey!"},
+		{Some([]byte("hey!")), []byte("hey!")},
+		{Some(dummyValuer{}), int64(271)},
+	} {
+		value, err := test.opt.Value()
+		if err != nil {
+			t.Fatalf("Failed getting value of %#v: %s", test.opt, err)
+		}
+
+		if !reflect.DeepEqual(value, test.expected) {
+			t.Errorf("Unexpected value for %#v: %#v, expected %#v", test.opt, value, test.expected)
+		}
+	}
+}
+
+func TestValueUintOverflow(t *testing.T) {
+	for _, opt := range []driver.Valuer{Some(uint64(math.MaxUint64)), Some(uint(math.MaxInt64 + 1))} {
+		if value, err := opt.Value(); err == nil {
+			t.Errorf("Expected an error for %#v, got %#v", opt, value)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package goption
+
+import "fmt"
+
+// TemplateFuncs returns functions for using options in text/template and
+// html/template:
+//
+//	isSome OPT            true if OPT is present
+//	unwrapOr DEFAULT OPT  the value of OPT, or DEFAULT if it's empty
+//	orDefault OPT         the value of OPT, or the zero value if it's empty
+//	someOr ARGS...        the value of the first present option in ARGS;
+//	                      arguments that aren't options count as present
+//
+// unwrapOr takes the option last so that it can be piped:
+//
+//	Hello {{.Nickname | unwrapOr "friend"}}
+//
+// Options are always true in {{if}} and {{with}}, so test them with isSome or
+// use ToRef, which is nil for empty options:
+//
+//	{{with .Nickname.ToRef}}Hello {{.}}{{end}}
+//
+// The functions return the underlying values, so html/template escapes them
+// as it would escape the values themselves.
+func TemplateFuncs() map[string]any {
+	return map[string]any{
+		"isSome": func(o any) (bool, error) {
+			_, ok, err := templateGet("isSome", o)
+			return ok, err
+		},
+		"unwrapOr": func(def, o any) (any, error) {
+			t, ok, err := templateGet("unwrapOr", o)
+			if err != nil || !ok {
+				return def, err
+			}
+			return t, nil
+		},
+		"orDefault": func(o any) (any, error) {
+			t, _, err := templateGet("orDefault", o)
+			return t, err
+		},
+		"someOr": func(args ...any) any {
+			for _, arg := range args {
+				opt, isOption := arg.(AnyOption)
+				if !isOption {
+					return arg
+				}
+				if t, ok := opt.OptionAny(); ok {
+					return t
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// templateGet returns the value of the option o passed to the function name.
+func templateGet(name string, o any) (any, bool, error) {
+	opt, isOption := o.(AnyOption)
+	if !isOption {
+		return nil, false, fmt.Errorf("%s: %T is not an Option", name, o)
+	}
+
+	t, ok := opt.OptionAny()
+	return t, ok, nil
+}
@@ -0,0 +1,87 @@
+package goption
+
+import (
+	htmltemplate "html/template"
+	"strings"
+	"testing"
+	"text/template"
+)
+
+type templateUser struct {
+	Name     string
+	Nickname Option[string]
+	Age      Option[int]
+	Address  Option[Bar]
+}
+
+const userTemplate = `{{if isSome .Nickname}}nick {{end}}` +
+	`hi {{.Nickname | unwrapOr "friend"}}, ` +
+	`{{someOr .Nickname .Name}}, ` +
+	`{{with .Age.ToRef}}age {{.}}, {{end}}` +
+	`{{with .Address.ToRef}}at {{.Baz}}, {{end}}` +
+	`{{orDefault .Age}}`
+
+func TestTextTemplate(t *testing.T) {
+	tmpl := template.Must(template.New("user").Funcs(TemplateFuncs()).Parse(userTemplate))
+
+	for _, test := range []struct {
+		user     templateUser
+		expected string
+	}{
+		{templateUser{Name: "jordan"}, "hi friend, jordan, 0"},
+		{
+			templateUser{Name: "jordan", Nickname: Some("jb"), Age: Some(0), Address: Some(Bar{Baz: "home"})},
+			"nick hi jb, jb, age 0, at home, 0",
+		},
+	} {
+		var out strings.Builder
+		if err := tmpl.Execute(&out, test.user); err != nil {
+			t.Fatalf("Failed executing template: %s", err)
+		}
+
+		if out.String() != test.expected {
+			t.Errorf("Unexpected output: %q, expected %q", out.String(), test.expected)
+		}
+	}
+}
+
+func TestHTMLTemplate(t *testing.T) {
+	tmpl := htmltemplate.Must(htmltemplate.New("user").Funcs(TemplateFuncs()).Parse(
+		`<p title="{{.Nickname | unwrapOr "<none>"}}">{{.Nickname | unwrapOr "friend"}}</p>` +
+			`{{with .Nickname.ToRef}}<b>{{.}}</b>{{end}}`,
+	))
+
+	var out strings.Builder
+	if err := tmpl.Execute(&out, templateUser{}); err != nil {
+		t.Fatalf("Failed executing template: %s", err)
+	}
+	if out.String() != `<p title="&lt;none&gt;">friend</p>` {
+		t.Errorf("Unexpected output: %q", out.String())
+	}
+
+	out.Reset()
+	if err := tmpl.Execute(&out, templateUser{Nickname: Some(`<script>"x"</script>`)}); err != nil {
+		t.Fatalf("Failed executing template: %s", err)
+	}
+	expected := `<p title="&lt;script&gt;&#34;x&#34;&lt;/script&gt;">&lt;script&gt;&#34;x&#34;&lt;/script&gt;</p>` +
+		`<b>&lt;script&gt;&#34;x&#34;&lt;/script&gt;</b>`
+	if out.String() != expected {
+		t.Errorf("Unexpected output: %q, expected %q", out.String(), expected)
+	}
+
+	out.Reset()
+	if err := tmpl.Execute(&out, struct{ Nickname Option[htmltemplate.HTML] }{Some(htmltemplate.HTML("<i>jb</i>"))}); err != nil {
+		t.Fatalf("Failed executing template: %s", err)
+	}
+	if !strings.Contains(out.String(), "><i>jb</i></p>") {
+		t.Errorf("Expected trusted HTML not to be escaped: %q", out.String())
+	}
+}
+
+func TestTemplateNotAnOption(t *testing.T) {
+	tmpl := template.Must(template.New("bad").Funcs(TemplateFuncs()).Parse(`{{isSome .}}`))
+	var out strings.Builder
+	if err := tmpl.Execute(&out, 3); err == nil || !strings.Contains(err.Error(), "int is not an Option") {
+		t.Errorf("Expected an error for a non option, got %v", err)
+	}
+}
@@ -6,7 +6,11 @@ import (
 
 // MarshalText marshals the underlying option data
 func (o Option[T]) MarshalText() ([]byte, error) {
-	var maybeMarshaler any = o.t
+	if !o.ok {
+		return []byte("null"), nil
+	}
+
+	var maybeMarshaler any = &o.t
 	if valuer, isMarshaler := maybeMarshaler.(encoding.TextMarshaler); isMarshaler {
 		return valuer.MarshalText()
 	}
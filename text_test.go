@@ -2,6 +2,7 @@ package goption
 
 import (
 	"testing"
+	"time"
 )
 
 func TestTextMarshal(t *testing.T) {
@@ -56,3 +57,46 @@ func TestTextUnmarshal(t *testing.T) {
 		t.Errorf("Expected optional value to be present.")
 	}
 }
+
+func TestTextMarshalNone(t *testing.T) {
+	encoded, err := None[time.Time]().MarshalText()
+	if err != nil {
+		t.Fatalf("Failed marshalling text: %s", err)
+	}
+
+	if string(encoded) != "null" {
+		t.Errorf("Unexpected encoded data: %s", string(encoded))
+	}
+
+	decoded := Some(time.Now())
+	if err := decoded.UnmarshalText(encoded); err != nil {
+		t.Fatalf("Failed unmarshalling text: %s", err)
+	} else if decoded.Ok() {
+		t.Errorf("Expected optional value to be empty.")
+	}
+}
+
+// ptrTextMarshaler only implements encoding.TextMarshaler on its pointer.
+type ptrTextMarshaler struct {
+	value string
+}
+
+func (p *ptrTextMarshaler) MarshalText() ([]byte, error) {
+	return []byte(p.value), nil
+}
+
+func (p *ptrTextMarshaler) UnmarshalText(data []byte) error {
+	p.value = string(data)
+	return nil
+}
+
+func TestTextMarshalPointerReceiver(t *testing.T) {
+	encoded, err := Some(ptrTextMarshaler{value: "hey!"}).MarshalText()
+	if err != nil {
+		t.Fatalf("Failed marshalling text: %s", err)
+	}
+
+	if string(encoded) != "hey!" {
+		t.Errorf("Unexpected encoded data: %s", string(encoded))
+	}
+}
@@ -0,0 +1,92 @@
+// Package tomloption adapts goption.Option for use with github.com/BurntSushi/toml.
+//
+// TOML has no null, so Option fields should be tagged with omitempty:
+//
+//	type Config struct {
+//	  Port tomloption.Option[int] `toml:"port,omitempty"`
+//	}
+//
+// Empty options are then left out of the encoded document, and absent keys
+// decode as empty options.
+package tomloption
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/BurntSushi/toml"
+	"github.com/jordan-bonecutter/goption"
+)
+
+// Option wraps goption.Option so that it implements toml.Marshaler and
+// toml.Unmarshaler. Every method of goption.Option is available on it.
+type Option[T any] struct {
+	goption.Option[T]
+}
+
+// Wrap returns o as a toml compatible Option.
+func Wrap[T any](o goption.Option[T]) Option[T] {
+	return Option[T]{o}
+}
+
+// Some returns an Option whose underlying value is present.
+func Some[T any](t T) Option[T] {
+	return Wrap(goption.Some(t))
+}
+
+// None returns an empty optional value.
+func None[T any]() Option[T] {
+	return Wrap(goption.None[T]())
+}
+
+// ErrNone is returned when marshalling an empty Option which wasn't omitted.
+var ErrNone = errors.New("tomloption: cannot marshal an empty option, TOML has no null (use omitempty)")
+
+// element is a sentinel which stops BurntSushi/toml from treating the
+// encoded array as an array of tables.
+const element = 0
+
+// MarshalTOML implements toml.Marshaler.
+// The underlying value is always written inline.
+func (o Option[T]) MarshalTOML() ([]byte, error) {
+	t, ok := o.Get()
+	if !ok {
+		return nil, ErrNone
+	}
+
+	// toml.Marshaler must produce a single value, but the encoder only writes
+	// tables inline when they're inside an array. Encode the value as the first
+	// element of an array and cut it back out.
+	encoded, err := toml.Marshal(map[string][]any{"v": {t, element}})
+	if err != nil {
+		return nil, err
+	}
+
+	prefix, suffix := []byte("v = ["), []byte(fmt.Sprintf(", %d]\n", element))
+	if !bytes.HasPrefix(encoded, prefix) || !bytes.HasSuffix(encoded, suffix) {
+		return nil, fmt.Errorf("tomloption: unexpected encoding for %T: %q", t, encoded)
+	}
+
+	return encoded[len(prefix) : len(encoded)-len(suffix)], nil
+}
+
+// UnmarshalTOML implements toml.Unmarshaler.
+func (o *Option[T]) UnmarshalTOML(data any) error {
+	// toml.Unmarshaler is handed the already parsed value, and toml has no way
+	// to decode that into a T. Round trip it through the encoder instead.
+	encoded, err := toml.Marshal(map[string]any{"v": data})
+	if err != nil {
+		return err
+	}
+
+	var decoded struct {
+		V T `toml:"v"`
+	}
+	if _, err := toml.Decode(string(encoded), &decoded); err != nil {
+		return err
+	}
+
+	*o = Some(decoded.V)
+	return nil
+}
@@ -0,0 +1,158 @@
+package tomloption
+
+import (
+	"bytes"
+	"errors"
+	"math/rand"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/jordan-bonecutter/goption/optiontest"
+)
+
+type limits struct {
+	Connections int         `toml:"connections"`
+	Idle        Option[int] `toml:"idle,omitempty"`
+}
+
+type database struct {
+	Host    string              `toml:"host"`
+	User    Option[string]      `toml:"user,omitempty"`
+	Limits  Option[limits]      `toml:"limits,omitempty"`
+	Started Option[time.Time]   `toml:"started,omitempty"`
+	Ratio   Option[float64]     `toml:"ratio,omitempty"`
+	Nested  Option[Option[int]] `toml:"nested,omitempty"`
+}
+
+type server struct {
+	Name    string           `toml:"name"`
+	Port    Option[int]      `toml:"port,omitempty"`
+	Tags    Option[[]string] `toml:"tags,omitempty"`
+	Enabled Option[bool]     `toml:"enabled,omitempty"`
+}
+
+type config struct {
+	Title    Option[string] `toml:"title,omitempty"`
+	Owner    Option[string] `toml:"owner,omitempty"`
+	Database database       `toml:"database"`
+	Servers  []server       `toml:"servers"`
+}
+
+var golden = config{
+	Title: Some("example"),
+	Database: database{
+		Host: "localhost",
+		User: Some("admin"),
+		Limits: Some(limits{
+			Connections: 10,
+			Idle:        Some(2),
+		}),
+		Started: Some(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)),
+		Nested:  Some(Some(0)),
+	},
+	Servers: []server{
+		{
+			Name:    "alpha",
+			Port:    Some(8080),
+			Tags:    Some([]string{"a", "b"}),
+			Enabled: Some(false),
+		},
+		{
+			Name: "beta",
+		},
+	},
+}
+
+func TestMarshalGolden(t *testing.T) {
+	expected, err := os.ReadFile("testdata/config.toml")
+	if err != nil {
+		t.Fatalf("Failed reading golden file: %s", err)
+	}
+
+	encoded, err := toml.Marshal(golden)
+	if err != nil {
+		t.Fatalf("Failed marshalling toml: %s", err)
+	}
+
+	if !bytes.Equal(encoded, expected) {
+		t.Errorf("Unexpected encoded data:\n%s\nexpected:\n%s", encoded, expected)
+	}
+}
+
+func TestUnmarshalGolden(t *testing.T) {
+	var decoded config
+	md, err := toml.DecodeFile("testdata/config.toml", &decoded)
+	if err != nil {
+		t.Fatalf("Failed unmarshalling toml: %s", err)
+	}
+
+	if undecoded := md.Undecoded(); len(undecoded) != 0 {
+		t.Errorf("Unexpected undecoded keys: %v", undecoded)
+	}
+
+	if !reflect.DeepEqual(decoded, golden) {
+		t.Errorf("Unexpected decoded data:\n%#v\nexpected:\n%#v", decoded, golden)
+	}
+}
+
+func TestMarshalNoneWithoutOmitEmpty(t *testing.T) {
+	_, err := toml.Marshal(struct {
+		Port Option[int] `toml:"port"`
+	}{})
+	if !errors.Is(err, ErrNone) {
+		t.Errorf("Expected ErrNone, got %v", err)
+	}
+}
+
+func TestUnmarshalTypeError(t *testing.T) {
+	var decoded struct {
+		Port Option[int] `toml:"port"`
+	}
+	if _, err := toml.Decode(`port = "abc"`, &decoded); err == nil {
+		t.Errorf("Expected an error decoding a string into an int")
+	}
+}
+
+func TestWrap(t *testing.T) {
+	if None[int]().Ok() {
+		t.Errorf("Expected none to be empty")
+	}
+	if v := Some(3).UnwrapOr(0); v != 3 {
+		t.Errorf("Expected 3, got %v", v)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	rand := rand.New(rand.NewSource(1))
+	values := make([]database, 200)
+	for i := range values {
+		v := database{
+			Host:  "localhost",
+			User:  Wrap(optiontest.Generate[string](rand, 10)),
+			Ratio: Wrap(optiontest.Generate[float64](rand, 10)),
+		}
+		if rand.Intn(2) == 0 {
+			v.Limits = Some(limits{
+				Connections: rand.Int(),
+				Idle:        Wrap(optiontest.Generate[int](rand, 10)),
+			})
+		}
+		if rand.Intn(2) == 0 {
+			v.Started = Some(time.Unix(rand.Int63n(1<<35), rand.Int63n(int64(time.Second))).UTC())
+		}
+		// Some(None) is encoded as None, so only nest present values.
+		if nested := optiontest.Generate[int](rand, 10); nested.Ok() {
+			v.Nested = Some(Wrap(nested))
+		}
+		values[i] = v
+	}
+
+	optiontest.RoundTrip(t, optiontest.Codec{
+		Name:      "toml",
+		Marshal:   toml.Marshal,
+		Unmarshal: toml.Unmarshal,
+	}, values)
+}
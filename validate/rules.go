@@ -0,0 +1,133 @@
+package validate
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"unicode/utf8"
+
+	"github.com/jordan-bonecutter/goption"
+)
+
+// Rule checks a value, returning an error describing why it's invalid.
+type Rule[T any] func(T) error
+
+// FieldError is a rule violation by a named field.
+type FieldError struct {
+	Field string
+	Err   error
+}
+
+// Error implements error.
+func (e *FieldError) Error() string {
+	return e.Field + ": " + e.Err.Error()
+}
+
+// Unwrap returns the error from the violated rule.
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// ErrRequired is reported by Required for empty options.
+var ErrRequired = errors.New("is required")
+
+// Field checks the value of o against rules if it is present.
+// It returns a *FieldError for the first rule which fails, and nil if o is
+// empty or every rule passes.
+func Field[T any](name string, o goption.Option[T], rules ...Rule[T]) error {
+	t, ok := o.Get()
+	if !ok {
+		return nil
+	}
+
+	for _, rule := range rules {
+		if err := rule(t); err != nil {
+			return &FieldError{Field: name, Err: err}
+		}
+	}
+
+	return nil
+}
+
+// Required is like Field, but an empty o fails with ErrRequired.
+func Required[T any](name string, o goption.Option[T], rules ...Rule[T]) error {
+	if !o.Ok() {
+		return &FieldError{Field: name, Err: ErrRequired}
+	}
+
+	return Field(name, o, rules...)
+}
+
+// Min returns a rule that requires values to be at least min.
+func Min[T cmp.Ordered](min T) Rule[T] {
+	return func(t T) error {
+		if t < min {
+			return fmt.Errorf("must be at least %v", min)
+		}
+		return nil
+	}
+}
+
+// Max returns a rule that requires values to be at most max.
+func Max[T cmp.Ordered](max T) Rule[T] {
+	return func(t T) error {
+		if t > max {
+			return fmt.Errorf("must be at most %v", max)
+		}
+		return nil
+	}
+}
+
+// Len returns a rule that requires values to have a length between min and
+// max inclusive. Strings are measured in runes, and slices, arrays and maps
+// in elements. Other values always fail.
+func Len[T any](min, max int) Rule[T] {
+	return func(t T) error {
+		n, ok := length(t)
+		switch {
+		case !ok:
+			return fmt.Errorf("has no length")
+		case n < min:
+			return fmt.Errorf("must have a length of at least %d", min)
+		case n > max:
+			return fmt.Errorf("must have a length of at most %d", max)
+		}
+		return nil
+	}
+}
+
+// length returns the length of v and true, or false if v has no length.
+func length(v any) (int, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.String:
+		return utf8.RuneCountInString(rv.String()), true
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return rv.Len(), true
+	}
+
+	return 0, false
+}
+
+// OneOf returns a rule that requires values to equal one of values.
+func OneOf[T comparable](values ...T) Rule[T] {
+	return func(t T) error {
+		if !slices.Contains(values, t) {
+			return fmt.Errorf("must be one of %v", values)
+		}
+		return nil
+	}
+}
+
+// Match returns a rule that requires strings to match re.
+func Match[T ~string](re *regexp.Regexp) Rule[T] {
+	return func(t T) error {
+		if !re.MatchString(string(t)) {
+			return fmt.Errorf("must match %s", re)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,70 @@
+package validate
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/jordan-bonecutter/goption"
+)
+
+func TestField(t *testing.T) {
+	if err := Field("age", goption.None[int](), Min(18)); err != nil {
+		t.Errorf("Expected None to be valid, got %s", err)
+	}
+	if err := Field("age", goption.Some(30), Min(18), Max(120)); err != nil {
+		t.Errorf("Expected Some(30) to be valid, got %s", err)
+	}
+
+	err := Field("age", goption.Some(130), Min(18), Max(120))
+	var fieldErr *FieldError
+	if !errors.As(err, &fieldErr) {
+		t.Fatalf("Expected a field error, got %v", err)
+	}
+	if fieldErr.Field != "age" || err.Error() != "age: must be at most 120" {
+		t.Errorf("Unexpected field error: %s", err)
+	}
+}
+
+func TestRequired(t *testing.T) {
+	err := Required("name", goption.None[string]())
+	if !errors.Is(err, ErrRequired) || err.Error() != "name: is required" {
+		t.Errorf("Expected None to be required, got %v", err)
+	}
+
+	if err := Required("name", goption.Some("jordan"), Len[string](1, 64)); err != nil {
+		t.Errorf("Expected Some to be valid, got %s", err)
+	}
+	if err := Required("name", goption.Some(""), Len[string](1, 64)); err == nil {
+		t.Errorf("Expected an empty name to fail")
+	}
+}
+
+func TestRules(t *testing.T) {
+	word := regexp.MustCompile(`^[a-z]+$`)
+	for _, test := range []struct {
+		name  string
+		err   error
+		valid bool
+	}{
+		{"min", Min(1)(1), true},
+		{"min fails", Min(1)(0), false},
+		{"max", Max(1.5)(1.5), true},
+		{"max fails", Max(1.5)(1.6), false},
+		{"max string", Max("m")("a"), true},
+		{"len runes", Len[string](2, 2)("é!"), true},
+		{"len too short", Len[string](2, 3)("a"), false},
+		{"len too long", Len[string](2, 3)("abcd"), false},
+		{"len slice", Len[[]int](1, 3)([]int{1, 2}), true},
+		{"len map", Len[map[string]int](1, 3)(map[string]int{}), false},
+		{"len without length", Len[int](0, 3)(1), false},
+		{"one of", OneOf("a", "b")("b"), true},
+		{"one of fails", OneOf("a", "b")("c"), false},
+		{"match", Match[string](word)("abc"), true},
+		{"match fails", Match[string](word)("ab1"), false},
+	} {
+		if valid := test.err == nil; valid != test.valid {
+			t.Errorf("%s: expected valid to be %v, got error %v", test.name, test.valid, test.err)
+		}
+	}
+}
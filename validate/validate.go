@@ -0,0 +1,206 @@
+// Package validate validates goption.Option values, either through
+// github.com/go-playground/validator/v10 or with its own rules.
+//
+// With validator, register the Option types which appear in your structs and
+// validate them with Struct, which skips the errors of empty Options:
+//
+//	v := validator.New()
+//	validate.Register(v, goption.Option[int]{}, goption.Option[string]{})
+//
+//	type User struct {
+//	  Name goption.Option[string] `validate:"required,min=2"`
+//	  Age  goption.Option[int]    `validate:"min=18"`
+//	}
+//
+//	err := validate.Struct(v, user)
+//
+// Rules then apply to the value inside Some, and None only fails required.
+//
+// Without validator, Field and Required check an Option against rules such
+// as Min and Match:
+//
+//	err := errors.Join(
+//	  validate.Required("name", user.Name, validate.Len[string](1, 64)),
+//	  validate.Field("age", user.Age, validate.Min(18)),
+//	)
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/jordan-bonecutter/goption"
+)
+
+// none is what validator sees in place of an empty Option.
+// A typed nil is used so that SkipNone can tell it apart from other nils.
+type none struct{}
+
+// Register registers a validator.CustomTypeFunc on v for the Option types of
+// options, so that rules see the value inside Some.
+// Every goption.Option type and wrapper type embedding it, such as
+// tomloption.Option, is supported.
+func Register(v *validator.Validate, options ...any) {
+	v.RegisterCustomTypeFunc(unwrap, options...)
+}
+
+// unwrap returns the value of the Option field, or a nil *none if it's empty.
+func unwrap(field reflect.Value) any {
+	if !field.CanInterface() {
+		return nil
+	}
+
+	o, isOption := field.Interface().(goption.AnyOption)
+	if !isOption {
+		return nil
+	}
+
+	value, ok := o.OptionAny()
+	if !ok {
+		return (*none)(nil)
+	}
+
+	return value
+}
+
+// Struct validates the struct s with v, like v.Struct, and then removes the
+// errors of empty Options with SkipNone.
+func Struct(v *validator.Validate, s any) error {
+	return SkipNone(v.Struct(s), s)
+}
+
+// Var validates field against tag with v, like v.Var, and then removes the
+// error of an empty Option unless tag has a required rule.
+func Var(v *validator.Validate, field any, tag string) error {
+	return skipNone(v.Var(field, tag), func(validator.FieldError) (string, bool) {
+		return tag, true
+	})
+}
+
+// SkipNone removes the errors which validator reported for empty Options of
+// the struct s, unless their field's validate tag has a rule of the required
+// family. It returns nil if no errors remain, and err unchanged if it is not
+// a validator.ValidationErrors.
+//
+// validator stops at the first rule which fails, so an empty Option tagged
+// "min=2,required" is reported as failing min. SkipNone looks at the whole
+// tag and reports it as failing required instead. Conditional rules such as
+// required_if are only evaluated when they come before the other rules.
+func SkipNone(err error, s any) error {
+	t := reflect.TypeOf(s)
+	return skipNone(err, func(fieldErr validator.FieldError) (string, bool) {
+		return fieldTag(t, fieldErr.StructNamespace())
+	})
+}
+
+// skipNone implements SkipNone, with tagOf returning the validate tag of the
+// field of an error.
+func skipNone(err error, tagOf func(validator.FieldError) (string, bool)) error {
+	var errs validator.ValidationErrors
+	if !errors.As(err, &errs) {
+		return err
+	}
+
+	kept := validator.ValidationErrors{}
+	for _, fieldErr := range errs {
+		if _, isNone := fieldErr.Value().(*none); !isNone || strings.HasPrefix(fieldErr.Tag(), "required") {
+			kept = append(kept, fieldErr)
+			continue
+		}
+
+		if tag, found := tagOf(fieldErr); found && hasRule(tag, "required") {
+			kept = append(kept, requiredError{fieldErr})
+		}
+	}
+
+	if len(kept) == 0 {
+		return nil
+	}
+	return kept
+}
+
+// fieldTag returns the validate tag which applies to the field named by the
+// struct namespace ns, such as "User.Addresses[0].Zip", of the struct type t.
+// For elements of slices and maps, that is the part of the tag after dive.
+func fieldTag(t reflect.Type, ns string) (string, bool) {
+	var tag string
+	for _, part := range strings.Split(ns, ".")[1:] {
+		name, indexes, _ := strings.Cut(part, "[")
+		for t != nil && t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		if t == nil || t.Kind() != reflect.Struct {
+			return "", false
+		}
+
+		field, found := t.FieldByName(name)
+		if !found {
+			return "", false
+		}
+		t, tag = field.Type, field.Tag.Get("validate")
+
+		for range strings.Count(indexes, "]") {
+			for t.Kind() == reflect.Pointer {
+				t = t.Elem()
+			}
+			switch t.Kind() {
+			case reflect.Slice, reflect.Array, reflect.Map:
+				t = t.Elem()
+			default:
+				return "", false
+			}
+
+			rules := strings.Split(tag, ",")
+			dive := slices.Index(rules, "dive")
+			if dive < 0 {
+				return "", false
+			}
+			tag = strings.Join(rules[dive+1:], ",")
+		}
+	}
+
+	return tag, true
+}
+
+// hasRule returns true if the validate tag has the rule name, on its own or
+// as one of several alternatives, before any dive.
+func hasRule(tag, name string) bool {
+	for _, rules := range strings.Split(tag, ",") {
+		if rules == "dive" {
+			return false
+		}
+		for _, rule := range strings.Split(rules, "|") {
+			if rule == name {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// requiredError is a validator.FieldError for an empty Option which validator
+// reported as failing another rule before it reached required.
+type requiredError struct {
+	validator.FieldError
+}
+
+func (e requiredError) Tag() string {
+	return "required"
+}
+
+func (e requiredError) ActualTag() string {
+	return "required"
+}
+
+func (e requiredError) Param() string {
+	return ""
+}
+
+func (e requiredError) Error() string {
+	return fmt.Sprintf("Key: '%s' Error:Field validation for '%s' failed on the 'required' tag", e.Namespace(), e.Field())
+}
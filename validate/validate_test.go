@@ -0,0 +1,175 @@
+package validate
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/jordan-bonecutter/goption"
+	"github.com/jordan-bonecutter/goption/tomloption"
+)
+
+type user struct {
+	Name  goption.Option[string] `validate:"required,min=2"`
+	Age   goption.Option[int]    `validate:"min=18"`
+	Email goption.Option[string] `validate:"omitempty,email"`
+	Port  tomloption.Option[int] `validate:"max=65535"`
+}
+
+func newValidator() *validator.Validate {
+	v := validator.New()
+	Register(v, goption.Option[string]{}, goption.Option[int]{}, tomloption.Option[int]{})
+	return v
+}
+
+// failedTags returns the field and tag of each validation error in err.
+func failedTags(t *testing.T, err error) map[string]string {
+	t.Helper()
+	if err == nil {
+		return nil
+	}
+
+	var errs validator.ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("Expected validation errors, got %v", err)
+	}
+
+	failed := map[string]string{}
+	for _, fieldErr := range errs {
+		failed[fieldErr.Field()] = fieldErr.Tag()
+	}
+	return failed
+}
+
+func TestValidatorSome(t *testing.T) {
+	v := newValidator()
+	err := Struct(v, user{
+		Name:  goption.Some("jordan"),
+		Age:   goption.Some(30),
+		Email: goption.Some("jordan@example.com"),
+		Port:  tomloption.Some(8080),
+	})
+	if err != nil {
+		t.Errorf("Expected a valid user, got %s", err)
+	}
+
+	err = Struct(v, user{
+		Name:  goption.Some("j"),
+		Age:   goption.Some(3),
+		Email: goption.Some("jordan"),
+		Port:  tomloption.Some(80800),
+	})
+	failed := failedTags(t, err)
+	if len(failed) != 4 || failed["Name"] != "min" || failed["Age"] != "min" || failed["Email"] != "email" || failed["Port"] != "max" {
+		t.Errorf("Unexpected validation errors: %v", failed)
+	}
+}
+
+func TestValidatorNonNull(t *testing.T) {
+	type strict struct {
+		Level goption.NonNull[int] `validate:"required,max=3"`
+	}
+
+	v := validator.New()
+	Register(v, goption.NonNull[int]{})
+	failed := failedTags(t, Struct(v, strict{Level: goption.NonNull[int]{Option: goption.Some(5)}}))
+	if len(failed) != 1 || failed["Level"] != "max" {
+		t.Errorf("Unexpected validation errors: %v", failed)
+	}
+
+	failed = failedTags(t, Struct(v, strict{}))
+	if len(failed) != 1 || failed["Level"] != "required" {
+		t.Errorf("Unexpected validation errors: %v", failed)
+	}
+}
+
+func TestValidatorNone(t *testing.T) {
+	v := newValidator()
+	err := v.Struct(user{})
+	failed := failedTags(t, err)
+	if failed["Name"] != "required" || failed["Age"] != "min" || failed["Port"] != "max" {
+		t.Errorf("Unexpected validation errors: %v", failed)
+	}
+	if _, ok := failed["Email"]; ok {
+		t.Errorf("Expected omitempty to skip None")
+	}
+
+	failed = failedTags(t, SkipNone(err, user{}))
+	if len(failed) != 1 || failed["Name"] != "required" {
+		t.Errorf("Expected only required to fail, got %v", failed)
+	}
+
+	if err := Struct(v, &user{Name: goption.Some("jordan")}); err != nil {
+		t.Errorf("Expected None to be skipped, got %s", err)
+	}
+}
+
+func TestValidatorRequiredLast(t *testing.T) {
+	type address struct {
+		Zip goption.Option[string] `validate:"len=5,required"`
+	}
+	type account struct {
+		Name      goption.Option[string] `validate:"min=2,required"`
+		Nick      goption.Option[string] `validate:"min=2|required"`
+		Addresses []address              `validate:"dive"`
+		Scores    []goption.Option[int]  `validate:"min=1,dive,max=10,required"`
+		Home      *address
+	}
+
+	v := newValidator()
+	err := Struct(v, account{
+		Addresses: []address{{Zip: goption.Some("12345")}, {}},
+		Scores:    []goption.Option[int]{goption.Some(1), goption.None[int]()},
+		Home:      &address{},
+	})
+	failed := map[string]string{}
+	var errs validator.ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("Expected validation errors, got %v", err)
+	}
+	for _, fieldErr := range errs {
+		failed[fieldErr.StructNamespace()] = fieldErr.Tag()
+	}
+
+	expected := map[string]string{
+		"account.Name":             "required",
+		"account.Nick":             "required",
+		"account.Addresses[1].Zip": "required",
+		"account.Scores[1]":        "required",
+		"account.Home.Zip":         "required",
+	}
+	if !reflect.DeepEqual(failed, expected) {
+		t.Errorf("Expected failures %v, got %v", expected, failed)
+	}
+	if msg := errs[0].Error(); !strings.Contains(msg, "failed on the 'required' tag") {
+		t.Errorf("Unexpected error message: %s", msg)
+	}
+}
+
+func TestSkipNoneOtherErrors(t *testing.T) {
+	if SkipNone(nil, user{}) != nil {
+		t.Errorf("Expected nil to stay nil")
+	}
+
+	err := errors.New("oops")
+	if SkipNone(err, user{}) != err {
+		t.Errorf("Expected other errors to be returned unchanged")
+	}
+}
+
+func TestValidatorVar(t *testing.T) {
+	v := newValidator()
+	if err := v.Var(goption.Some(5), "min=10"); err == nil {
+		t.Errorf("Expected Some(5) to fail min=10")
+	}
+	if err := Var(v, goption.None[int](), "min=10"); err != nil {
+		t.Errorf("Expected None to be skipped, got %s", err)
+	}
+	for _, tag := range []string{"required", "min=10,required"} {
+		if err := Var(v, goption.None[int](), tag); err == nil {
+			t.Errorf("Expected None to fail %s", tag)
+		}
+	}
+}
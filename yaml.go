@@ -0,0 +1,26 @@
+package goption
+
+// MarshalYAML implements yaml.Marshaler for gopkg.in/yaml.v3.
+// Empty optional values are marshalled as null.
+func (o Option[T]) MarshalYAML() (any, error) {
+	if !o.ok {
+		return nil, nil
+	}
+
+	return o.t, nil
+}
+
+// UnmarshalYAML implements the function based yaml.Unmarshaler which is
+// supported by both gopkg.in/yaml.v2 and gopkg.in/yaml.v3.
+// yaml never calls this for null or ~, so like encoding/json those leave the
+// option empty when decoding into a new value. Decoding errors from the
+// underlying value are returned as is so they keep their line numbers.
+func (o *Option[T]) UnmarshalYAML(unmarshal func(any) error) error {
+	var t *T
+	if err := unmarshal(&t); err != nil {
+		return err
+	}
+
+	*o = FromRef(t)
+	return nil
+}
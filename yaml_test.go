@@ -0,0 +1,97 @@
+package goption
+
+import (
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+type yamlFoo struct {
+	Stuff  Option[Bar] `yaml:"stuff"`
+	Count  Option[int] `yaml:"count"`
+	Things []int       `yaml:"things"`
+}
+
+func TestYAMLMarshal(t *testing.T) {
+	foo := yamlFoo{
+		Things: []int{1, 2, 3},
+	}
+	encoded, err := yaml.Marshal(foo)
+	if err != nil {
+		t.Fatalf("Failed marshalling yaml: %s", err)
+	}
+
+	if string(encoded) != "stuff: null\ncount: null\nthings:\n    - 1\n    - 2\n    - 3\n" {
+		t.Errorf("Unexpected encoded data: %s", string(encoded))
+	}
+
+	foo.Stuff = Some(Bar{Baz: "hey!"})
+	foo.Count = Some(0)
+	encoded, err = yaml.Marshal(foo)
+	if err != nil {
+		t.Fatalf("Failed marshalling yaml: %s", err)
+	}
+
+	if string(encoded) != "stuff:\n    baz: hey!\ncount: 0\nthings:\n    - 1\n    - 2\n    - 3\n" {
+		t.Errorf("Unexpected encoded data: %s", string(encoded))
+	}
+}
+
+func TestYAMLMarshalOmitEmpty(t *testing.T) {
+	type omitter struct {
+		Count Option[int]       `yaml:"count,omitempty"`
+		Zero  Option[fooZeroer] `yaml:"zero,omitempty"`
+		Empty Option[int]       `yaml:"empty,omitempty"`
+	}
+
+	encoded, err := yaml.Marshal(omitter{
+		Count: Some(0),
+		Zero:  Some(fooZeroer(true)),
+	})
+	if err != nil {
+		t.Fatalf("Failed marshalling yaml: %s", err)
+	}
+
+	if string(encoded) != "count: 0\n" {
+		t.Errorf("Unexpected encoded data: %s", string(encoded))
+	}
+}
+
+func TestYAMLUnmarshal(t *testing.T) {
+	for _, doc := range []string{
+		"things: [1, 2, 3]",
+		"stuff: null\ncount: null\nthings: [1, 2, 3]",
+		"stuff: ~\ncount: ~\nthings: [1, 2, 3]",
+	} {
+		var foo yamlFoo
+		if err := yaml.Unmarshal([]byte(doc), &foo); err != nil {
+			t.Errorf("Failed unmarshalling into foo: %s", err)
+		} else if len(foo.Things) != 3 || foo.Things[0] != 1 || foo.Things[1] != 2 || foo.Things[2] != 3 {
+			t.Errorf("Failed unmarshalling foo.Things: %v", foo.Things)
+		} else if foo.Stuff.Ok() || foo.Count.Ok() {
+			t.Errorf("Expected optional values to be empty for %q.", doc)
+		}
+	}
+
+	var foo yamlFoo
+	if err := yaml.Unmarshal([]byte("stuff:\n  baz: hey!\ncount: 0\n"), &foo); err != nil {
+		t.Errorf("Failed unmarshalling into foo: %s", err)
+	} else if !foo.Stuff.Ok() || foo.Stuff.Unwrap().Baz != "hey!" {
+		t.Errorf("Expected optional value to be present.")
+	} else if !foo.Count.Ok() || foo.Count.Unwrap() != 0 {
+		t.Errorf("Expected optional count to be present.")
+	}
+}
+
+func TestYAMLUnmarshalError(t *testing.T) {
+	var foo yamlFoo
+	err := yaml.Unmarshal([]byte("things: []\ncount: abc\n"), &foo)
+	if err == nil {
+		t.Fatalf("Expected an error unmarshalling a string into an int")
+	}
+
+	if !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("Expected error to include the line number: %s", err)
+	}
+}